@@ -1,16 +1,34 @@
 package main
 
 import (
+	"context"
 	"log/slog"
 	"os"
+	"os/signal"
 	"strconv"
+	"syscall"
 
 	"github.com/joho/godotenv"
 	"github.com/p-shah256/tracker/internal/api"
 	"github.com/p-shah256/tracker/pkg/logger"
 )
 
+// subcommands work on the database directly and don't need the LLM
+var subcommands = map[string]func(args []string) int{
+	"import": runImport,
+	"export": runExport,
+}
+
 func main() {
+	if len(os.Args) > 1 {
+		if run, ok := subcommands[os.Args[1]]; ok {
+			// stdout is the subcommand's output, only warnings are logged and to stderr
+			slog.SetDefault(slog.New(logger.NewColoredHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn})))
+			godotenv.Load()
+			os.Exit(run(os.Args[2:]))
+		}
+	}
+
 	logger.Setup()
 
 	if err := godotenv.Load(); err != nil {
@@ -38,8 +56,11 @@ func main() {
 		os.Exit(1)
 	}
 
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	slog.Info("Server initialized", "port", port)
-	if err := server.Start(); err != nil {
+	if err := server.Start(ctx); err != nil {
 		slog.Error("Error starting API server", "error", err)
 		os.Exit(1)
 	}
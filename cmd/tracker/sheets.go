@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/p-shah256/tracker/internal/api"
+	"github.com/p-shah256/tracker/internal/applications"
+	"github.com/p-shah256/tracker/internal/sheets"
+	"github.com/p-shah256/tracker/internal/storage"
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+// mappingFlag collects repeated -map field=Column flags.
+type mappingFlag []string
+
+func (m *mappingFlag) String() string { return strings.Join(*m, ",") }
+
+func (m *mappingFlag) Set(v string) error {
+	*m = append(*m, v)
+	return nil
+}
+
+// openApplications opens the database, the caller closes the store.
+func openApplications() (*applications.Service, *storage.SQLite, error) {
+	store, err := storage.OpenSQLite(api.DBPath())
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot open database: %w", err)
+	}
+	return applications.NewService(store), store, nil
+}
+
+// runImport is `tracker import [flags] file.csv`, "-" reads stdin.
+func runImport(args []string) int {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	var mappings mappingFlag
+	fs.Var(&mappings, "map", "map a field to a column, field=Column (repeatable); fields: "+strings.Join(sheets.Fields(), ", "))
+	userID := fs.String("user", "", "user id for rows without a user_id column")
+	dryRun := fs.Bool("dry-run", false, "check every row without importing")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: tracker import [flags] file.csv")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+
+	mapping, err := sheets.ParseMapping(mappings)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	var in io.Reader = os.Stdin
+	if path := fs.Arg(0); path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		defer f.Close()
+		in = f
+	}
+	svc, store, err := openApplications()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer store.Close()
+
+	result, err := sheets.Import(context.Background(), svc, in, sheets.ImportOptions{Mapping: mapping, UserID: *userID, DryRun: *dryRun})
+	if err != nil {
+		// rows before the failure are imported already
+		if result != nil {
+			printImport(result)
+		}
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	printImport(result)
+	if len(result.Errors) > 0 {
+		return 1
+	}
+	return 0
+}
+
+func printImport(result *types.ImportResult) {
+	for _, name := range sheets.Fields() {
+		if column, ok := result.Columns[name]; ok {
+			fmt.Printf("%-10s <- %s\n", name, column)
+		}
+	}
+	if len(result.Unmapped) > 0 {
+		fmt.Printf("ignored columns: %s\n", strings.Join(result.Unmapped, ", "))
+	}
+	for _, e := range result.Errors {
+		if e.Column != "" {
+			fmt.Fprintf(os.Stderr, "row %d: %s %q: %s\n", e.Row, e.Column, e.Value, e.Message)
+		} else {
+			fmt.Fprintf(os.Stderr, "row %d: %s\n", e.Row, e.Message)
+		}
+	}
+	for _, app := range result.Applications {
+		for _, dup := range app.Duplicates {
+			fmt.Fprintf(os.Stderr, "warning: %s at %s looks like application %s\n", app.Position, app.Company, dup.ApplicationID)
+		}
+	}
+	if result.DryRun {
+		fmt.Printf("%d of %d rows would be imported\n", len(result.Applications), result.Rows)
+	} else {
+		fmt.Printf("imported %d of %d rows\n", result.Imported, result.Rows)
+	}
+}
+
+// runExport is `tracker export [flags]`, written to stdout unless -o is given.
+func runExport(args []string) int {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	format := fs.String("format", "", "csv or xlsx (default: from -o, else csv)")
+	out := fs.String("o", "", "output file")
+	status := fs.String("status", "", "only these statuses, comma separated")
+	userID := fs.String("user", "", "only this user's applications")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: tracker export [flags]")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return 2
+	}
+
+	if *format == "" && *out != "" {
+		*format = strings.TrimPrefix(filepath.Ext(*out), ".")
+	}
+	f, err := sheets.ParseFormat(*format)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	filter := storage.ApplicationFilter{UserID: *userID, WithHistory: true}
+	if *status != "" {
+		for _, s := range strings.Split(*status, ",") {
+			st, err := applications.ParseStatus(s)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				return 2
+			}
+			filter.Statuses = append(filter.Statuses, st)
+		}
+	}
+
+	svc, store, err := openApplications()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer store.Close()
+	apps, err := svc.List(context.Background(), filter)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if *out == "" {
+		if err := sheets.Export(os.Stdout, apps, f); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		return 0
+	}
+
+	file, err := os.Create(*out)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	err = sheets.Export(file, apps, f)
+	// closing flushes the file, a half-written export is worse than none
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(*out)
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	fmt.Fprintf(os.Stderr, "exported %d applications to %s\n", len(apps), *out)
+	return 0
+}
@@ -0,0 +1,336 @@
+package analytics
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/p-shah256/tracker/internal/applications"
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+const unknown = "unknown"
+
+// scoreBuckets are the bucket edges, the last bucket runs to 10 inclusive.
+var scoreBuckets = []float64{0, 5, 6, 7, 8, 10}
+
+// responses are the statuses that mean the company replied.
+var responses = []types.ApplicationStatus{
+	types.StatusScreening,
+	types.StatusInterviewing,
+	types.StatusOffer,
+	types.StatusRejected,
+}
+
+// path is what one application's history says about it.
+type path struct {
+	app *types.Application
+	// furthest is the index in the pipeline of the furthest stage reached, -1 for none
+	furthest int
+	// days spent in each stage the application has left
+	days map[types.ApplicationStatus]float64
+	// closedFrom is the stage it was in when rejected or withdrawn
+	closedFrom types.ApplicationStatus
+	closedAs   types.ApplicationStatus
+	responded  bool
+}
+
+// stages are the open statuses in pipeline order.
+var stages = func() []types.ApplicationStatus {
+	var open []types.ApplicationStatus
+	for _, status := range applications.Statuses() {
+		if !applications.Closed(status) {
+			open = append(open, status)
+		}
+	}
+	return open
+}()
+
+var (
+	appliedStage   = slices.Index(stages, types.StatusApplied)
+	interviewStage = slices.Index(stages, types.StatusInterviewing)
+)
+
+func (p *path) sent() bool {
+	return p.furthest >= appliedStage
+}
+
+func (p *path) interviewed() bool {
+	return p.furthest >= interviewStage
+}
+
+// walk replays an application's history. Histories of merged applications interleave
+// the events of both, so a change to the status it's already in is skipped and the
+// furthest stage only ever moves forward.
+func walk(app *types.Application) *path {
+	p := &path{app: app, furthest: -1, days: map[types.ApplicationStatus]float64{}}
+	events := slices.Clone(app.History)
+	slices.SortStableFunc(events, func(a, b types.StatusChange) int { return a.At.Compare(b.At) })
+
+	var current types.ApplicationStatus
+	var since time.Time
+	for _, e := range events {
+		if e.To == current {
+			continue
+		}
+		if current != "" && !applications.Closed(current) {
+			p.days[current] += e.At.Sub(since).Hours() / 24
+		}
+		if applications.Closed(e.To) {
+			switch {
+			case current == "":
+				// one tracked only once it was closed was sent, nobody records a
+				// rejection for a job they never applied to
+				p.furthest = appliedStage
+				p.closedFrom, p.closedAs = types.StatusApplied, e.To
+			case !applications.Closed(current):
+				p.closedFrom, p.closedAs = current, e.To
+			}
+		} else {
+			p.furthest = max(p.furthest, slices.Index(stages, e.To))
+		}
+		if p.sent() && slices.Contains(responses, e.To) {
+			p.responded = true
+		}
+		current, since = e.To, e.At
+	}
+	// the stage it's still in has no duration yet
+	if !applications.Closed(current) {
+		delete(p.days, current)
+	}
+	return p
+}
+
+// Funnel computes the pipeline analytics of applications, which need their histories.
+// runScores holds the overall score of each score run by request ID; an application is
+// scored by the run it came from and falls back to its own score without one.
+func Funnel(apps []types.Application, runScores map[string]float64) types.Funnel {
+	paths := make([]*path, len(apps))
+	for i := range apps {
+		paths[i] = walk(&apps[i])
+	}
+
+	funnel := types.Funnel{Applications: len(apps), Stages: []types.FunnelStage{}}
+	for i, stage := range stages {
+		s := types.FunnelStage{Status: stage}
+		var days []float64
+		for _, p := range paths {
+			if p.furthest >= i {
+				s.Reached++
+			}
+			if p.furthest > i {
+				s.Advanced++
+			}
+			if p.app.Status == stage {
+				s.Current++
+			}
+			if p.closedFrom == stage {
+				switch p.closedAs {
+				case types.StatusRejected:
+					s.Rejected++
+				case types.StatusWithdrawn:
+					s.Withdrawn++
+				}
+			}
+			if d, ok := p.days[stage]; ok {
+				days = append(days, d)
+			}
+		}
+		if i < len(stages)-1 {
+			s.ConversionRate = rate(s.Advanced, s.Reached)
+		}
+		if m, ok := median(days); ok {
+			m = round(m, 1)
+			s.MedianDays = &m
+		}
+		funnel.Stages = append(funnel.Stages, s)
+	}
+
+	var sent []*path
+	for _, p := range paths {
+		if p.sent() {
+			sent = append(sent, p)
+		}
+	}
+	funnel.Sent = len(sent)
+	funnel.ByLevel = groupBy(sent, func(app *types.Application) string { return app.Level })
+	sizes := applications.CompanySizes()
+	funnel.ByCompanySize = groupBy(sent, func(app *types.Application) string { return app.CompanySize })
+	// sizes read better smallest first than by volume
+	slices.SortStableFunc(funnel.ByCompanySize, func(a, b types.ResponseRate) int {
+		return cmp.Compare(sizeOrder(sizes, a.Group), sizeOrder(sizes, b.Group))
+	})
+	funnel.Score = scoreOutcome(sent, runScores)
+	return funnel
+}
+
+// groupBy computes response rates per group, biggest group first and unknown last.
+// Groups are case-insensitive and named after the first spelling seen.
+func groupBy(paths []*path, key func(*types.Application) string) []types.ResponseRate {
+	groups := []types.ResponseRate{}
+	index := map[string]int{}
+	for _, p := range paths {
+		name := strings.TrimSpace(key(p.app))
+		if name == "" {
+			name = unknown
+		}
+		k := strings.ToLower(name)
+		i, ok := index[k]
+		if !ok {
+			i = len(groups)
+			index[k] = i
+			groups = append(groups, types.ResponseRate{Group: name})
+		}
+		groups[i].Sent++
+		if p.responded {
+			groups[i].Responses++
+		}
+		if p.interviewed() {
+			groups[i].Interviews++
+		}
+	}
+	for i := range groups {
+		groups[i].ResponseRate = rate(groups[i].Responses, groups[i].Sent)
+		groups[i].InterviewRate = rate(groups[i].Interviews, groups[i].Sent)
+	}
+	slices.SortStableFunc(groups, func(a, b types.ResponseRate) int {
+		if (a.Group == unknown) != (b.Group == unknown) {
+			if a.Group == unknown {
+				return 1
+			}
+			return -1
+		}
+		return cmp.Or(cmp.Compare(b.Sent, a.Sent), cmp.Compare(a.Group, b.Group))
+	})
+	return groups
+}
+
+func sizeOrder(sizes []string, group string) int {
+	if i := slices.Index(sizes, group); i >= 0 {
+		return i
+	}
+	return len(sizes)
+}
+
+func scoreOutcome(sent []*path, runScores map[string]float64) types.ScoreOutcome {
+	outcome := types.ScoreOutcome{Buckets: []types.ScoreBucket{}}
+	for i := 0; i+1 < len(scoreBuckets); i++ {
+		outcome.Buckets = append(outcome.Buckets, types.ScoreBucket{Min: scoreBuckets[i], Max: scoreBuckets[i+1]})
+	}
+
+	var scores, outcomes, interviewed, notInterviewed []float64
+	for _, p := range sent {
+		score, ok := scoreOf(p.app, runScores)
+		if !ok {
+			continue
+		}
+		b := &outcome.Buckets[bucket(score)]
+		b.Sent++
+		scores = append(scores, score)
+		if p.interviewed() {
+			b.Interviews++
+			outcomes = append(outcomes, 1)
+			interviewed = append(interviewed, score)
+		} else {
+			outcomes = append(outcomes, 0)
+			notInterviewed = append(notInterviewed, score)
+		}
+	}
+	for i := range outcome.Buckets {
+		outcome.Buckets[i].InterviewRate = rate(outcome.Buckets[i].Interviews, outcome.Buckets[i].Sent)
+	}
+
+	outcome.Scored = len(scores)
+	if r, ok := correlation(scores, outcomes); ok {
+		r = round(r, 3)
+		outcome.Correlation = &r
+	}
+	outcome.InterviewedMean = mean(interviewed)
+	outcome.NotInterviewedMean = mean(notInterviewed)
+	return outcome
+}
+
+// scoreOf is the score of the run an application came from, else its own score.
+func scoreOf(app *types.Application, runScores map[string]float64) (float64, bool) {
+	if score, ok := runScores[app.ScoreRunID]; ok && app.ScoreRunID != "" {
+		return score, true
+	}
+	if app.Score == nil {
+		return 0, false
+	}
+	return *app.Score, true
+}
+
+func bucket(score float64) int {
+	for i := len(scoreBuckets) - 2; i > 0; i-- {
+		if score >= scoreBuckets[i] {
+			return i
+		}
+	}
+	return 0
+}
+
+// correlation is Pearson's r, which with a 0/1 outcome is the point-biserial correlation.
+func correlation(x, y []float64) (float64, bool) {
+	n := float64(len(x))
+	if len(x) < 3 {
+		return 0, false
+	}
+	var sx, sy float64
+	for i := range x {
+		sx += x[i]
+		sy += y[i]
+	}
+	mx, my := sx/n, sy/n
+	var cov, vx, vy float64
+	for i := range x {
+		dx, dy := x[i]-mx, y[i]-my
+		cov += dx * dy
+		vx += dx * dx
+		vy += dy * dy
+	}
+	if vx == 0 || vy == 0 {
+		return 0, false
+	}
+	return cov / math.Sqrt(vx*vy), true
+}
+
+func mean(values []float64) *float64 {
+	if len(values) == 0 {
+		return nil
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	m := round(sum/float64(len(values)), 2)
+	return &m
+}
+
+func median(values []float64) (float64, bool) {
+	if len(values) == 0 {
+		return 0, false
+	}
+	sorted := slices.Sorted(slices.Values(values))
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2, true
+	}
+	return sorted[mid], true
+}
+
+// rate is part/whole rounded to three places, nil when whole is 0.
+func rate(part, whole int) *float64 {
+	if whole == 0 {
+		return nil
+	}
+	r := round(float64(part)/float64(whole), 3)
+	return &r
+}
+
+func round(x float64, places int) float64 {
+	p := math.Pow(10, float64(places))
+	return math.Round(x*p) / p
+}
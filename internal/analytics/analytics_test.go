@@ -0,0 +1,193 @@
+package analytics
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+var day0 = time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+// history builds status changes one day apart, starting at day0.
+func history(statuses ...types.ApplicationStatus) []types.StatusChange {
+	changes := make([]types.StatusChange, len(statuses))
+	for i, status := range statuses {
+		changes[i] = types.StatusChange{To: status, At: day0.AddDate(0, 0, i)}
+	}
+	return changes
+}
+
+func TestWalk(t *testing.T) {
+	tests := []struct {
+		name          string
+		history       []types.StatusChange
+		wantFurthest  types.ApplicationStatus
+		wantSent      bool
+		wantResponded bool
+		wantClosed    types.ApplicationStatus
+		wantDays      map[types.ApplicationStatus]float64
+	}{
+		{
+			name:         "saved only",
+			history:      history(types.StatusSaved),
+			wantFurthest: types.StatusSaved,
+			wantDays:     map[types.ApplicationStatus]float64{},
+		},
+		{
+			name:         "applied and waiting",
+			history:      history(types.StatusSaved, types.StatusApplied),
+			wantFurthest: types.StatusApplied,
+			wantSent:     true,
+			wantDays:     map[types.ApplicationStatus]float64{types.StatusSaved: 1},
+		},
+		{
+			name:          "rejected after screening",
+			history:       history(types.StatusApplied, types.StatusScreening, types.StatusRejected),
+			wantFurthest:  types.StatusScreening,
+			wantSent:      true,
+			wantResponded: true,
+			wantClosed:    types.StatusScreening,
+			wantDays:      map[types.ApplicationStatus]float64{types.StatusApplied: 1, types.StatusScreening: 1},
+		},
+		{
+			name:         "withdrawn before applying",
+			history:      history(types.StatusSaved, types.StatusWithdrawn),
+			wantFurthest: types.StatusSaved,
+			wantClosed:   types.StatusSaved,
+			wantDays:     map[types.ApplicationStatus]float64{types.StatusSaved: 1},
+		},
+		{
+			name:          "rejected at creation",
+			history:       history(types.StatusRejected),
+			wantFurthest:  types.StatusApplied,
+			wantSent:      true,
+			wantResponded: true,
+			wantClosed:    types.StatusApplied,
+			wantDays:      map[types.ApplicationStatus]float64{},
+		},
+		{
+			name:         "withdrawn at creation",
+			history:      history(types.StatusWithdrawn),
+			wantFurthest: types.StatusApplied,
+			wantSent:     true,
+			wantClosed:   types.StatusApplied,
+			wantDays:     map[types.ApplicationStatus]float64{},
+		},
+		{
+			name: "merged histories never move back",
+			history: []types.StatusChange{
+				{To: types.StatusApplied, At: day0},
+				{To: types.StatusApplied, At: day0.AddDate(0, 0, 1)},
+				{To: types.StatusInterviewing, At: day0.AddDate(0, 0, 2)},
+				{To: types.StatusScreening, At: day0.AddDate(0, 0, 3)},
+			},
+			wantFurthest:  types.StatusInterviewing,
+			wantSent:      true,
+			wantResponded: true,
+			wantDays:      map[types.ApplicationStatus]float64{types.StatusApplied: 2, types.StatusInterviewing: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := walk(&types.Application{History: tt.history})
+			if got := stages[p.furthest]; got != tt.wantFurthest {
+				t.Errorf("furthest = %s, want %s", got, tt.wantFurthest)
+			}
+			if p.sent() != tt.wantSent || p.responded != tt.wantResponded {
+				t.Errorf("sent %v responded %v, want %v %v", p.sent(), p.responded, tt.wantSent, tt.wantResponded)
+			}
+			if p.closedFrom != tt.wantClosed {
+				t.Errorf("closed from %q, want %q", p.closedFrom, tt.wantClosed)
+			}
+			if len(p.days) != len(tt.wantDays) {
+				t.Errorf("days = %v, want %v", p.days, tt.wantDays)
+			}
+			for status, want := range tt.wantDays {
+				if p.days[status] != want {
+					t.Errorf("days in %s = %v, want %v", status, p.days[status], want)
+				}
+			}
+		})
+	}
+}
+
+func TestMedian(t *testing.T) {
+	tests := []struct {
+		values []float64
+		want   float64
+		ok     bool
+	}{
+		{nil, 0, false},
+		{[]float64{4}, 4, true},
+		{[]float64{9, 1, 5}, 5, true},
+		{[]float64{8, 2, 4, 6}, 5, true},
+	}
+	for _, tt := range tests {
+		got, ok := median(tt.values)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("median(%v) = %v %v, want %v %v", tt.values, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestCorrelation(t *testing.T) {
+	tests := []struct {
+		name string
+		x, y []float64
+		want float64
+		ok   bool
+	}{
+		{"too few", []float64{1, 2}, []float64{0, 1}, 0, false},
+		{"higher scores interviewed", []float64{1, 2, 3, 4}, []float64{0, 0, 1, 1}, 0.894, true},
+		{"lower scores interviewed", []float64{9, 8, 2, 1}, []float64{0, 0, 1, 1}, -0.990, true},
+		{"all ended alike", []float64{3, 6, 9}, []float64{1, 1, 1}, 0, false},
+		{"all scored alike", []float64{7, 7, 7}, []float64{0, 1, 0}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := correlation(tt.x, tt.y)
+			if ok != tt.ok || math.Abs(got-tt.want) > 0.001 {
+				t.Errorf("correlation = %v %v, want %v %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestFunnel(t *testing.T) {
+	score := func(s float64) *float64 { return &s }
+	apps := []types.Application{
+		{Level: "Senior", Status: types.StatusInterviewing, ScoreRunID: "run-high", Score: score(2),
+			History: history(types.StatusApplied, types.StatusInterviewing)},
+		{Level: "senior", Status: types.StatusRejected, Score: score(4),
+			History: history(types.StatusRejected)},
+		{Level: "Junior", Status: types.StatusApplied, ScoreRunID: "run-gone", Score: score(5.5),
+			History: history(types.StatusApplied)},
+		{Status: types.StatusSaved, Score: score(9), History: history(types.StatusSaved)},
+	}
+	funnel := Funnel(apps, map[string]float64{"run-high": 8.5})
+
+	if funnel.Applications != 4 || funnel.Sent != 3 {
+		t.Errorf("applications %d sent %d, want 4 and 3", funnel.Applications, funnel.Sent)
+	}
+	for _, s := range funnel.Stages {
+		if s.Status == types.StatusApplied && (s.Reached != 3 || s.Rejected != 1) {
+			t.Errorf("applied stage = %+v, want 3 reached and 1 rejected", s)
+		}
+	}
+	if len(funnel.ByLevel) != 2 || funnel.ByLevel[0].Group != "Senior" || funnel.ByLevel[0].Sent != 2 || funnel.ByLevel[0].Responses != 2 {
+		t.Errorf("by level = %+v", funnel.ByLevel)
+	}
+
+	// the interviewed application is scored by its run, not the 2 it was saved with
+	if funnel.Score.Scored != 3 || funnel.Score.InterviewedMean == nil || *funnel.Score.InterviewedMean != 8.5 {
+		t.Errorf("score outcome = %+v", funnel.Score)
+	}
+	if top := funnel.Score.Buckets[len(funnel.Score.Buckets)-1]; top.Sent != 1 || top.Interviews != 1 {
+		t.Errorf("top bucket = %+v", top)
+	}
+	if funnel.Score.Correlation == nil || *funnel.Score.Correlation <= 0 {
+		t.Errorf("correlation = %v, want positive", funnel.Score.Correlation)
+	}
+}
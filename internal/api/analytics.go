@@ -0,0 +1,51 @@
+package api
+
+import (
+	stderrors "errors"
+	"net/http"
+
+	"github.com/p-shah256/tracker/internal/analytics"
+	"github.com/p-shah256/tracker/internal/storage"
+	"github.com/p-shah256/tracker/pkg/errors"
+	"github.com/p-shah256/tracker/pkg/logger"
+)
+
+// handleFunnel reports conversion, time in stage, response rates and score against
+// outcome over the applications matching the same filters as GET /applications.
+func (s *Server) handleFunnel(w http.ResponseWriter, r *http.Request) {
+	requestID := logger.GetRequestID(r.Context())
+
+	filter, err := applicationFilter(r)
+	if err != nil {
+		RespondWithError(w, errors.ErrBadRequest(err.Error()).WithRequestID(requestID))
+		return
+	}
+	filter.WithHistory = true
+	apps, err := s.applications.List(r.Context(), filter)
+	if err != nil {
+		respondWithStorageError(w, err, requestID)
+		return
+	}
+	// score against outcome uses the score of the run an application came from
+	runScores := map[string]float64{}
+	for _, app := range apps {
+		if app.ScoreRunID == "" {
+			continue
+		}
+		if _, ok := runScores[app.ScoreRunID]; ok {
+			continue
+		}
+		run, err := s.store.GetScoreRun(r.Context(), app.ScoreRunID)
+		if stderrors.Is(err, storage.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			respondWithStorageError(w, err, requestID)
+			return
+		}
+		if run.Result != nil {
+			runScores[app.ScoreRunID] = run.Result.OverallScore
+		}
+	}
+	RespondWithJSON(w, http.StatusOK, analytics.Funnel(apps, runScores))
+}
@@ -1,24 +1,44 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/p-shah256/tracker/internal/applications"
+	"github.com/p-shah256/tracker/internal/cleaner"
+	"github.com/p-shah256/tracker/internal/contacts"
+	"github.com/p-shah256/tracker/internal/fetch"
+	"github.com/p-shah256/tracker/internal/lint"
 	"github.com/p-shah256/tracker/internal/llm"
+	"github.com/p-shah256/tracker/internal/matcher"
+	"github.com/p-shah256/tracker/internal/reminders"
+	"github.com/p-shah256/tracker/internal/storage"
+	"github.com/p-shah256/tracker/internal/taxonomy"
+	"github.com/p-shah256/tracker/internal/verify"
 	"github.com/p-shah256/tracker/pkg/errors"
 	"github.com/p-shah256/tracker/pkg/logger"
 	"github.com/p-shah256/tracker/pkg/types"
 )
 
 type Server struct {
-	port      int
-	llmClient llm.LLM
+	port         int
+	llmClient    llm.LLM
+	fetcher      *fetch.Fetcher
+	cleaner      *cleaner.Cleaner
+	matcher      *matcher.Matcher
+	store        storage.Repository
+	applications *applications.Service
+	reminders    *reminders.Scheduler
+	contacts     *contacts.Service
 }
 
 func NewServer(port int) (*Server, error) {
@@ -26,12 +46,88 @@ func NewServer(port int) (*Server, error) {
 	if err != nil {
 		return nil, fmt.Errorf("cannot init llm client %w", err)
 	}
+	skills := taxonomy.Default()
+	if path := os.Getenv("SKILLS_FILE"); path != "" {
+		if skills, err = taxonomy.Load(path); err != nil {
+			return nil, fmt.Errorf("cannot load skill taxonomy %w", err)
+		}
+		slog.Info("Loaded skill taxonomy", "path", path, "skills", len(skills.Skills()))
+	}
+	llm.SetTaxonomy(skills)
+	if band := os.Getenv("BULLET_LENGTH_BAND"); band != "" {
+		b, err := strconv.ParseFloat(band, 64)
+		if err != nil || b <= 0 || b >= 1 {
+			return nil, fmt.Errorf("BULLET_LENGTH_BAND must be a fraction between 0 and 1, got %q", band)
+		}
+		llm.SetLengthBand(verify.LengthBand(b))
+	}
+	if models := os.Getenv("SCORE_MODELS"); models != "" {
+		var allowed []string
+		for _, model := range strings.Split(models, ",") {
+			if model = strings.TrimSpace(model); model != "" {
+				allowed = append(allowed, model)
+			}
+		}
+		llm.SetAllowedModels(allowed)
+	}
+	dbPath := DBPath()
+	store, err := storage.OpenSQLite(dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open database %w", err)
+	}
+	slog.Info("Opened database", "path", dbPath)
+
+	policy := reminders.DefaultPolicy()
+	if days, ok, err := countEnv("FOLLOW_UP_DAYS"); err != nil {
+		return nil, err
+	} else if ok {
+		policy.FollowUpAfter = time.Duration(days) * 24 * time.Hour
+	}
+	if hours, ok, err := countEnv("INTERVIEW_PREP_HOURS"); err != nil {
+		return nil, err
+	} else if ok {
+		policy.PrepBefore = time.Duration(hours) * time.Hour
+	}
+	// the scheduler logs due reminders itself, the notifiers are for delivery elsewhere
+	notifier := reminders.Notifiers{}
+	if url := os.Getenv("REMINDER_WEBHOOK_URL"); url != "" {
+		notifier = append(notifier, reminders.NewWebhookNotifier(url))
+	}
+
 	return &Server{
-		port:      port,
-		llmClient: *llm,
+		port:         port,
+		llmClient:    *llm,
+		fetcher:      fetch.New(fetch.DefaultConfig()),
+		cleaner:      cleaner.NewCleaner(),
+		matcher:      matcher.New(skills),
+		store:        store,
+		applications: applications.NewService(store),
+		reminders:    reminders.NewScheduler(store, notifier, policy),
+		contacts:     contacts.NewService(store),
 	}, nil
 }
 
+// DBPath is the database file, DB_PATH or tracker.db in the working directory.
+func DBPath() string {
+	if path := os.Getenv("DB_PATH"); path != "" {
+		return path
+	}
+	return "tracker.db"
+}
+
+// countEnv reads a non-negative integer setting, ok is false when it isn't set.
+func countEnv(name string) (int, bool, error) {
+	v := os.Getenv(name)
+	if v == "" {
+		return 0, false, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, false, fmt.Errorf("%s must be a non-negative integer, got %q", name, v)
+	}
+	return n, true, nil
+}
+
 func enableCORS(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
@@ -57,15 +153,86 @@ func applyMiddleware(handler http.HandlerFunc, methods ...string) http.HandlerFu
 	)
 }
 
-func (s *Server) Start() error {
+// shutdownTimeout is how long in-flight requests get to finish once Start's context is done.
+const shutdownTimeout = 10 * time.Second
+
+// Start serves the API and runs the reminder scheduler until ctx is done, then shuts the
+// server down gracefully and closes the database.
+func (s *Server) Start(ctx context.Context) error {
 	http.HandleFunc("/score", applyMiddleware(s.handleScore, http.MethodPost))
+	http.HandleFunc("/jobDescription/clean", applyMiddleware(s.handleCleanJobDescription, http.MethodPost))
 	http.HandleFunc("/transformSection", applyMiddleware(s.handleTransformSection, http.MethodPost))
+	http.HandleFunc("/lint", applyMiddleware(s.handleLint, http.MethodPost))
+	http.HandleFunc("/coverLetter", applyMiddleware(s.handleCoverLetter, http.MethodPost))
+	http.HandleFunc("/interviewPrep", applyMiddleware(s.handleInterviewPrep, http.MethodPost))
+	http.HandleFunc("/tailorProfile", applyMiddleware(s.handleTailorProfile, http.MethodPost))
 	http.HandleFunc("/upload/resume", applyMiddleware(s.handleUploadResume, http.MethodPost))
+	http.HandleFunc("/resumes", applyMiddleware(s.handleCreateResume, http.MethodPost))
+	http.HandleFunc("/resumes/{id}/bullets", applyMiddleware(s.handleChooseBullets, http.MethodPut))
+	http.HandleFunc("/resumes/{id}/render", applyMiddleware(s.handleRenderResume, http.MethodGet))
+	http.HandleFunc("/resumes/{id}/versions", applyMiddleware(s.handleResumeVersions, http.MethodGet))
+	http.HandleFunc("/resumes/{id}/diff", applyMiddleware(s.handleResumeDiff, http.MethodGet))
+	http.HandleFunc("/applications", applyMiddleware(s.handleApplications, http.MethodGet, http.MethodPost))
+	http.HandleFunc("/applications/import", applyMiddleware(s.handleImportApplications, http.MethodPost))
+	http.HandleFunc("/applications/export", applyMiddleware(s.handleExportApplications, http.MethodGet))
+	http.HandleFunc("/applications/{id}", applyMiddleware(s.handleApplication, http.MethodGet, http.MethodPatch, http.MethodDelete))
+	http.HandleFunc("/applications/{id}/status", applyMiddleware(s.handleApplicationStatus, http.MethodPost))
+	http.HandleFunc("/applications/{id}/duplicates", applyMiddleware(s.handleApplicationDuplicates, http.MethodGet))
+	http.HandleFunc("/applications/{id}/merge", applyMiddleware(s.handleMergeApplication, http.MethodPost))
+	http.HandleFunc("/applications/{id}/reminders", applyMiddleware(s.handleApplicationReminders, http.MethodGet, http.MethodPost))
+	http.HandleFunc("/applications/{id}/contacts", applyMiddleware(s.handleApplicationContacts, http.MethodGet, http.MethodPost))
+	http.HandleFunc("/applications/{id}/contacts/{contact_id}", applyMiddleware(s.handleUnlinkContact, http.MethodDelete))
+	http.HandleFunc("/applications/{id}/interactions", applyMiddleware(s.handleApplicationInteractions, http.MethodGet))
+	http.HandleFunc("/contacts", applyMiddleware(s.handleContacts, http.MethodGet, http.MethodPost))
+	http.HandleFunc("/contacts/{id}", applyMiddleware(s.handleContact, http.MethodGet, http.MethodPatch, http.MethodDelete))
+	http.HandleFunc("/contacts/{id}/interactions", applyMiddleware(s.handleContactInteractions, http.MethodGet, http.MethodPost))
+	http.HandleFunc("/interactions/{id}", applyMiddleware(s.handleDeleteInteraction, http.MethodDelete))
+	http.HandleFunc("/applications/{id}/interviews", applyMiddleware(s.handleApplicationInterviews, http.MethodGet, http.MethodPost))
+	http.HandleFunc("/interviews/{id}", applyMiddleware(s.handleInterview, http.MethodPatch, http.MethodDelete))
+	http.HandleFunc("/users/{id}/calendar", applyMiddleware(s.handleCalendarToken, http.MethodPost))
+	http.HandleFunc("/calendar/{token}", applyMiddleware(s.handleCalendar, http.MethodGet))
+	http.HandleFunc("/reminders", applyMiddleware(s.handleReminders, http.MethodGet))
+	http.HandleFunc("/reminders/{id}", applyMiddleware(s.handleDeleteReminder, http.MethodDelete))
+	http.HandleFunc("/analytics/funnel", applyMiddleware(s.handleFunnel, http.MethodGet))
+	http.HandleFunc("/jobs/{id}/scores", applyMiddleware(s.handleScoreHistory, http.MethodGet))
 	http.HandleFunc("/health", applyMiddleware(s.handleHealthCheck, http.MethodGet))
 
-	addr := fmt.Sprintf(":%d", s.port)
+	ctx, stop := context.WithCancel(ctx)
+	defer stop()
+	scheduler := make(chan struct{})
+	go func() {
+		defer close(scheduler)
+		s.reminders.Run(ctx)
+	}()
+
+	srv := &http.Server{Addr: fmt.Sprintf(":%d", s.port)}
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.ListenAndServe()
+	}()
 	slog.Info("Starting API server", "port", s.port)
-	return http.ListenAndServe(addr, nil)
+
+	var err error
+	select {
+	case err = <-serveErr:
+	case <-ctx.Done():
+		slog.Info("Shutting down API server")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		err = srv.Shutdown(shutdownCtx)
+	}
+
+	// the scheduler may be in the middle of a tick, let it finish before the database goes away
+	stop()
+	<-scheduler
+	s.llmClient.Close()
+	if closeErr := s.store.Close(); closeErr != nil {
+		slog.Error("Failed to close database", "err", closeErr)
+		if err == nil {
+			err = closeErr
+		}
+	}
+	return err
 }
 
 func (s *Server) handleScore(w http.ResponseWriter, r *http.Request) {
@@ -81,17 +248,38 @@ func (s *Server) handleScore(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if req.JobDescText == "" {
-		RespondWithError(w, errors.ErrBadRequest("Job description is required").WithRequestID(requestID))
-		return
+	if req.ResumeVersionID != "" {
+		apiErr := s.loadResumeVersion(r, &req)
+		if apiErr != nil {
+			RespondWithError(w, apiErr.WithRequestID(requestID))
+			return
+		}
 	}
-
 	if req.Resume == "" {
 		RespondWithError(w, errors.ErrBadRequest("Resume content is required").WithRequestID(requestID))
 		return
 	}
 
-	skills, err := s.llmClient.ExtractSkills(req.JobDescText)
+	allowed := s.llmClient.AllowedModels()
+	for _, model := range req.Models {
+		if !slices.Contains(allowed, model) {
+			RespondWithError(w, errors.ErrBadRequest(fmt.Sprintf("Model %q is not allowed, use one of %s", model, strings.Join(allowed, ", "))).WithRequestID(requestID))
+			return
+		}
+	}
+	variants := llm.ScoreVariants(req.Samples, req.Temperatures, req.Models)
+	if req.Samples < 0 || len(variants) > llm.MaxScoreSamples {
+		RespondWithError(w, errors.ErrBadRequest(fmt.Sprintf("Samples must be between 1 and %d", llm.MaxScoreSamples)).WithRequestID(requestID))
+		return
+	}
+
+	jobDesc, pageURL, apiErr := s.loadJobDescription(r, req.JobDescText, req.JobDescURL)
+	if apiErr != nil {
+		RespondWithError(w, apiErr.WithRequestID(requestID))
+		return
+	}
+
+	skills, err := s.llmClient.ExtractSkillsFromPage(jobDesc, pageURL)
 	if err != nil {
 		slog.Error("Skills extraction failed",
 			"err", err,
@@ -101,7 +289,12 @@ func (s *Server) handleScore(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	scored, err := s.llmClient.ScoreResume(skills, req.Resume)
+	var scored *types.ScoredResume
+	if req.Samples > 1 || len(req.Temperatures) > 0 || len(req.Models) > 0 {
+		scored, err = s.llmClient.ScoreResumeSamples(skills, req.Resume, variants)
+	} else {
+		scored, err = s.llmClient.ScoreResume(skills, req.Resume)
+	}
 	if err != nil {
 		slog.Error("Resume scoring failed",
 			"err", err,
@@ -110,6 +303,22 @@ func (s *Server) handleScore(w http.ResponseWriter, r *http.Request) {
 		RespondWithError(w, errors.ErrLLMProcessing("Failed to score resume: "+err.Error()).WithRequestID(requestID))
 		return
 	}
+	scored.KeywordCoverage = s.matcher.Coverage(skills, req.Resume)
+
+	// a lost history entry isn't worth failing the score over
+	if err := s.store.SaveJob(r.Context(), &storage.Job{RequestID: requestID, URL: pageURL, Description: jobDesc, Skills: skills}); err != nil {
+		slog.Error("Failed to save job description", "err", err, "request_id", requestID)
+	} else if err := s.store.SaveScoreRun(r.Context(), &storage.ScoreRun{
+		RequestID:       requestID,
+		JobID:           requestID,
+		ResumeVersionID: req.ResumeVersionID,
+		Resume:          req.Resume,
+		Result:          scored,
+		Model:           s.llmClient.ScoreModel(variants),
+		PromptVersion:   llm.ScorePromptVersion,
+	}); err != nil {
+		slog.Error("Failed to save score run", "err", err, "request_id", requestID)
+	}
 
 	RespondWithJSON(w, http.StatusOK, scored)
 }
@@ -117,7 +326,7 @@ func (s *Server) handleScore(w http.ResponseWriter, r *http.Request) {
 func (s *Server) handleTransformSection(w http.ResponseWriter, r *http.Request) {
 	requestID := logger.GetRequestID(r.Context())
 
-	var req types.Section
+	var req types.TransformRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		slog.Error("Failed to parse section",
 			"err", err,
@@ -132,6 +341,11 @@ func (s *Server) handleTransformSection(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if !llm.ValidTransformMode(req.Mode) {
+		RespondWithError(w, errors.ErrBadRequest(fmt.Sprintf("Mode must be %q or %q", llm.ModeTruthful, llm.ModeAggressive)).WithRequestID(requestID))
+		return
+	}
+
 	transformedItems, err := s.llmClient.TransformResumeBullets(&req)
 	if err != nil {
 		slog.Error("Section transformation failed",
@@ -142,6 +356,11 @@ func (s *Server) handleTransformSection(w http.ResponseWriter, r *http.Request)
 		RespondWithError(w, errors.ErrLLMProcessing("Failed to transform section: "+err.Error()).WithRequestID(requestID))
 		return
 	}
+	lint.Annotate(transformedItems.Items)
+
+	if err := s.store.SaveTransform(r.Context(), &storage.Transform{RequestID: requestID, Request: req, Result: transformedItems}); err != nil {
+		slog.Error("Failed to save transform", "err", err, "request_id", requestID)
+	}
 
 	RespondWithJSON(w, http.StatusOK, transformedItems)
 }
@@ -202,7 +421,10 @@ func (s *Server) handleHealthCheck(w http.ResponseWriter, r *http.Request) {
 	}
 
 	llmStatus := "unknown"
-	dbStatus := "unknown"
+	dbStatus := "available"
+	if err := s.store.Ping(r.Context()); err != nil {
+		dbStatus = "unavailable: " + err.Error()
+	}
 	// This would depend on your LLM client implementation
 	// For example, you could add a Ping() method to your llm.LLM type
 	// if err := s.llmClient.Ping(); err == nil {
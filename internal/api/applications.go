@@ -0,0 +1,250 @@
+package api
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/p-shah256/tracker/internal/applications"
+	"github.com/p-shah256/tracker/internal/contacts"
+	"github.com/p-shah256/tracker/internal/reminders"
+	"github.com/p-shah256/tracker/internal/sheets"
+	"github.com/p-shah256/tracker/internal/storage"
+	"github.com/p-shah256/tracker/pkg/errors"
+	"github.com/p-shah256/tracker/pkg/logger"
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+// handleApplications lists applications (GET) or starts tracking a new one (POST).
+func (s *Server) handleApplications(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost {
+		s.handleCreateApplication(w, r)
+		return
+	}
+	requestID := logger.GetRequestID(r.Context())
+
+	filter, err := applicationFilter(r)
+	if err != nil {
+		RespondWithError(w, errors.ErrBadRequest(err.Error()).WithRequestID(requestID))
+		return
+	}
+	apps, err := s.applications.List(r.Context(), filter)
+	if err != nil {
+		respondWithStorageError(w, err, requestID)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, apps)
+}
+
+func (s *Server) handleCreateApplication(w http.ResponseWriter, r *http.Request) {
+	requestID := logger.GetRequestID(r.Context())
+
+	var app types.Application
+	if err := json.NewDecoder(r.Body).Decode(&app); err != nil {
+		slog.Error("Failed to parse application", "err", err, "request_id", requestID)
+		RespondWithError(w, errors.ErrBadRequest("Invalid JSON format: "+err.Error()).WithRequestID(requestID))
+		return
+	}
+	app.ID = ""
+
+	if err := s.applications.Create(r.Context(), &app); err != nil {
+		respondWithStorageError(w, err, requestID)
+		return
+	}
+	slog.Info("Application created", "application_id", app.ID, "status", app.Status, "request_id", requestID)
+	if err := s.reminders.StatusChanged(r.Context(), &app); err != nil {
+		slog.Error("Failed to schedule reminders", "err", err, "application_id", app.ID, "request_id", requestID)
+	}
+	RespondWithJSON(w, http.StatusCreated, app)
+}
+
+// handleApplication reads (GET), edits (PATCH) or deletes (DELETE) one application.
+// PATCH only changes the fields present in the body.
+func (s *Server) handleApplication(w http.ResponseWriter, r *http.Request) {
+	requestID := logger.GetRequestID(r.Context())
+	id := r.PathValue("id")
+
+	switch r.Method {
+	case http.MethodDelete:
+		if err := s.applications.Delete(r.Context(), id); err != nil {
+			respondWithStorageError(w, err, requestID)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+
+	case http.MethodPatch:
+		app, err := s.applications.Get(r.Context(), id)
+		if err != nil {
+			respondWithStorageError(w, err, requestID)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(app); err != nil {
+			RespondWithError(w, errors.ErrBadRequest("Invalid JSON format: "+err.Error()).WithRequestID(requestID))
+			return
+		}
+		app.ID = id
+		if err := s.applications.Update(r.Context(), app); err != nil {
+			respondWithStorageError(w, err, requestID)
+			return
+		}
+		RespondWithJSON(w, http.StatusOK, app)
+		return
+	}
+
+	app, err := s.applications.Get(r.Context(), id)
+	if err != nil {
+		respondWithStorageError(w, err, requestID)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, app)
+}
+
+func (s *Server) handleApplicationStatus(w http.ResponseWriter, r *http.Request) {
+	requestID := logger.GetRequestID(r.Context())
+	id := r.PathValue("id")
+
+	var req types.StatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		RespondWithError(w, errors.ErrBadRequest("Invalid JSON format: "+err.Error()).WithRequestID(requestID))
+		return
+	}
+	var at time.Time
+	if req.At != nil {
+		at = *req.At
+	}
+
+	app, err := s.applications.SetStatus(r.Context(), id, req.Status, req.Note, at)
+	if err != nil {
+		respondWithStorageError(w, err, requestID)
+		return
+	}
+	slog.Info("Application status changed", "application_id", id, "status", app.Status, "request_id", requestID)
+	// the status change stands even if its reminders couldn't be scheduled
+	if err := s.reminders.StatusChanged(r.Context(), app); err != nil {
+		slog.Error("Failed to schedule reminders", "err", err, "application_id", id, "request_id", requestID)
+	}
+	if req.InterviewAt != nil {
+		interview := types.Interview{ApplicationID: id, StartsAt: *req.InterviewAt}
+		if err := s.applications.AddInterview(r.Context(), &interview); err != nil {
+			slog.Error("Failed to add interview", "err", err, "application_id", id, "request_id", requestID)
+		} else if err := s.reminders.InterviewScheduled(r.Context(), &interview); err != nil {
+			slog.Error("Failed to schedule reminders", "err", err, "interview_id", interview.ID, "request_id", requestID)
+		}
+	}
+	RespondWithJSON(w, http.StatusOK, app)
+}
+
+// handleApplicationDuplicates lists tracked applications that look like the same posting.
+func (s *Server) handleApplicationDuplicates(w http.ResponseWriter, r *http.Request) {
+	requestID := logger.GetRequestID(r.Context())
+
+	app, err := s.applications.Get(r.Context(), r.PathValue("id"))
+	if err != nil {
+		respondWithStorageError(w, err, requestID)
+		return
+	}
+	duplicates, err := s.applications.Duplicates(r.Context(), app)
+	if err != nil {
+		respondWithStorageError(w, err, requestID)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, duplicates)
+}
+
+// handleMergeApplication folds the duplicate_id application into this one.
+func (s *Server) handleMergeApplication(w http.ResponseWriter, r *http.Request) {
+	requestID := logger.GetRequestID(r.Context())
+	id := r.PathValue("id")
+
+	var req types.MergeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		RespondWithError(w, errors.ErrBadRequest("Invalid JSON format: "+err.Error()).WithRequestID(requestID))
+		return
+	}
+	if req.DuplicateID == "" {
+		RespondWithError(w, errors.ErrBadRequest("duplicate_id is required").WithRequestID(requestID))
+		return
+	}
+
+	app, err := s.applications.Merge(r.Context(), id, req.DuplicateID)
+	if err != nil {
+		respondWithStorageError(w, err, requestID)
+		return
+	}
+	slog.Info("Applications merged", "application_id", id, "duplicate_id", req.DuplicateID, "request_id", requestID)
+	RespondWithJSON(w, http.StatusOK, app)
+}
+
+// applicationFilter reads ?status=applied,screening&company=acme&user_id=&min_score=6&since=2025-01-01&until=...&limit=&offset=
+func applicationFilter(r *http.Request) (storage.ApplicationFilter, error) {
+	q := r.URL.Query()
+	var filter storage.ApplicationFilter
+
+	if statuses := q.Get("status"); statuses != "" {
+		for _, s := range strings.Split(statuses, ",") {
+			status, err := applications.ParseStatus(s)
+			if err != nil {
+				return filter, fmt.Errorf("status must be one of %v", applications.Statuses())
+			}
+			filter.Statuses = append(filter.Statuses, status)
+		}
+	}
+	filter.Company = strings.TrimSpace(q.Get("company"))
+	filter.UserID = strings.TrimSpace(q.Get("user_id"))
+
+	if v := q.Get("min_score"); v != "" {
+		score, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return filter, fmt.Errorf("min_score must be a number")
+		}
+		filter.MinScore = &score
+	}
+	for name, dst := range map[string]*time.Time{"since": &filter.Since, "until": &filter.Until} {
+		if v := q.Get(name); v != "" {
+			t, err := parseTime(v)
+			if err != nil {
+				return filter, fmt.Errorf("%s must be a date (2006-01-02) or RFC 3339 time", name)
+			}
+			*dst = t
+		}
+	}
+	for name, dst := range map[string]*int{"limit": &filter.Limit, "offset": &filter.Offset} {
+		if v := q.Get(name); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				return filter, fmt.Errorf("%s must be a non-negative integer", name)
+			}
+			*dst = n
+		}
+	}
+	return filter, nil
+}
+
+func parseTime(v string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, v); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, v)
+}
+
+// respondWithStorageError maps repository and validation errors to status codes.
+func respondWithStorageError(w http.ResponseWriter, err error, requestID string) {
+	switch {
+	case stderrors.Is(err, storage.ErrNotFound):
+		RespondWithError(w, errors.ErrNotFound(err.Error()).WithRequestID(requestID))
+	case stderrors.Is(err, applications.ErrInvalid), stderrors.Is(err, reminders.ErrInvalid), stderrors.Is(err, sheets.ErrInvalid),
+		stderrors.Is(err, contacts.ErrInvalid):
+		RespondWithError(w, errors.ErrBadRequest(err.Error()).WithRequestID(requestID))
+	case stderrors.Is(err, applications.ErrInvalidTransition), stderrors.Is(err, storage.ErrConflict):
+		RespondWithError(w, errors.ErrConflict(err.Error()).WithRequestID(requestID))
+	default:
+		slog.Error("Storage request failed", "err", err, "request_id", requestID)
+		RespondWithError(w, errors.ErrInternalServer(err.Error()).WithRequestID(requestID))
+	}
+}
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/p-shah256/tracker/internal/ical"
+	"github.com/p-shah256/tracker/internal/storage"
+	"github.com/p-shah256/tracker/pkg/errors"
+	"github.com/p-shah256/tracker/pkg/logger"
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+const (
+	// calendarPast keeps recent interviews in the feed so they don't vanish from calendars the moment they end
+	calendarPast = 30 * 24 * time.Hour
+	// reminderLength is how long a follow-up shows up for, a point in time is easy to miss
+	reminderLength = 15 * time.Minute
+)
+
+// handleCalendarToken issues a user a new calendar feed URL, the previous one stops working.
+// The feed is looked up by a random token, a user ID alone is easy to guess.
+func (s *Server) handleCalendarToken(w http.ResponseWriter, r *http.Request) {
+	requestID := logger.GetRequestID(r.Context())
+	userID := strings.TrimSpace(r.PathValue("id"))
+	if userID == "" {
+		RespondWithError(w, errors.ErrBadRequest("user id is required").WithRequestID(requestID))
+		return
+	}
+
+	token := rand.Text()
+	if err := s.store.SetCalendarToken(r.Context(), userID, token); err != nil {
+		respondWithStorageError(w, err, requestID)
+		return
+	}
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	RespondWithJSON(w, http.StatusCreated, types.CalendarFeed{
+		UserID: userID,
+		Token:  token,
+		URL:    fmt.Sprintf("%s://%s/calendar/%s.ics", scheme, r.Host, token),
+	})
+}
+
+// handleCalendar serves a user's interviews, application deadlines and pending follow-ups
+// as an iCalendar feed calendar apps can subscribe to.
+func (s *Server) handleCalendar(w http.ResponseWriter, r *http.Request) {
+	requestID := logger.GetRequestID(r.Context())
+	userID, err := s.store.CalendarUser(r.Context(), strings.TrimSuffix(r.PathValue("token"), ".ics"))
+	if err != nil {
+		respondWithStorageError(w, err, requestID)
+		return
+	}
+	now := time.Now()
+
+	interviews, err := s.applications.Interviews(r.Context(), storage.InterviewFilter{UserID: userID, Since: now.Add(-calendarPast)})
+	if err != nil {
+		respondWithStorageError(w, err, requestID)
+		return
+	}
+	apps, err := s.applications.List(r.Context(), storage.ApplicationFilter{UserID: userID})
+	if err != nil {
+		respondWithStorageError(w, err, requestID)
+		return
+	}
+	pending, err := s.reminders.List(r.Context(), storage.ReminderFilter{UserID: userID, Pending: true})
+	if err != nil {
+		respondWithStorageError(w, err, requestID)
+		return
+	}
+
+	urls := make(map[string]string, len(apps))
+	cal := ical.Calendar{
+		ProdID: "-//tracker//Job applications//EN",
+		Name:   "Job applications",
+	}
+	for _, app := range apps {
+		urls[app.ID] = app.URL
+		// a deadline only matters until the application is sent
+		if app.Deadline == nil || app.Status != types.StatusSaved {
+			continue
+		}
+		cal.Events = append(cal.Events, ical.Event{
+			UID:         "deadline-" + app.ID + "@tracker",
+			Start:       *app.Deadline,
+			AllDay:      true,
+			Summary:     fmt.Sprintf("Apply by: %s at %s", app.Position, app.Company),
+			Description: app.Notes,
+			URL:         app.URL,
+		})
+	}
+	for _, interview := range interviews {
+		description := interview.Position + " at " + interview.Company
+		if interview.Notes != "" {
+			description += "\n\n" + interview.Notes
+		}
+		cal.Events = append(cal.Events, ical.Event{
+			UID:         "interview-" + interview.ID + "@tracker",
+			Start:       interview.StartsAt,
+			End:         interview.EndsAt,
+			Summary:     fmt.Sprintf("%s: %s", interview.Company, interview.Title),
+			Description: description,
+			Location:    interview.Location,
+			URL:         urls[interview.ApplicationID],
+			Alarm:       s.reminders.PrepBefore(),
+		})
+	}
+	for _, reminder := range pending {
+		// prep reminders are the interviews' alarms already
+		if reminder.Kind == types.ReminderInterviewPrep {
+			continue
+		}
+		cal.Events = append(cal.Events, ical.Event{
+			UID:         "reminder-" + reminder.ID + "@tracker",
+			Start:       reminder.DueAt,
+			End:         reminder.DueAt.Add(reminderLength),
+			Summary:     reminder.Message,
+			Description: reminder.Position + " at " + reminder.Company,
+			URL:         urls[reminder.ApplicationID],
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := cal.Write(&buf, now); err != nil {
+		slog.Error("Failed to write calendar", "err", err, "request_id", requestID)
+		RespondWithError(w, errors.ErrInternalServer("Failed to write calendar: "+err.Error()).WithRequestID(requestID))
+		return
+	}
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="applications.ics"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
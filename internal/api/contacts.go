@@ -0,0 +1,202 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/p-shah256/tracker/internal/storage"
+	"github.com/p-shah256/tracker/pkg/errors"
+	"github.com/p-shah256/tracker/pkg/logger"
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+// handleContacts lists (GET) or adds (POST) contacts. GET takes ?user_id=&company=&role=
+// &application_id= and ?stale_days=14 for the ones not contacted in that long.
+func (s *Server) handleContacts(w http.ResponseWriter, r *http.Request) {
+	requestID := logger.GetRequestID(r.Context())
+
+	if r.Method == http.MethodPost {
+		var contact types.Contact
+		if err := json.NewDecoder(r.Body).Decode(&contact); err != nil {
+			RespondWithError(w, errors.ErrBadRequest("Invalid JSON format: "+err.Error()).WithRequestID(requestID))
+			return
+		}
+		contact.ID = ""
+		if err := s.contacts.Create(r.Context(), &contact); err != nil {
+			respondWithStorageError(w, err, requestID)
+			return
+		}
+		slog.Info("Contact created", "contact_id", contact.ID, "request_id", requestID)
+		RespondWithJSON(w, http.StatusCreated, contact)
+		return
+	}
+
+	q := r.URL.Query()
+	filter := storage.ContactFilter{
+		UserID:        strings.TrimSpace(q.Get("user_id")),
+		Company:       strings.TrimSpace(q.Get("company")),
+		Role:          types.ContactRole(strings.TrimSpace(q.Get("role"))),
+		ApplicationID: strings.TrimSpace(q.Get("application_id")),
+	}
+	if v := q.Get("stale_days"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil || days < 0 {
+			RespondWithError(w, errors.ErrBadRequest("stale_days must be a non-negative integer").WithRequestID(requestID))
+			return
+		}
+		filter.ContactedBefore = time.Now().AddDate(0, 0, -days)
+	}
+	contacts, err := s.contacts.List(r.Context(), filter)
+	if err != nil {
+		respondWithStorageError(w, err, requestID)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, contacts)
+}
+
+// handleContact reads (GET), edits (PATCH) or deletes (DELETE) one contact. PATCH only
+// changes the fields present in the body.
+func (s *Server) handleContact(w http.ResponseWriter, r *http.Request) {
+	requestID := logger.GetRequestID(r.Context())
+	id := r.PathValue("id")
+
+	if r.Method == http.MethodDelete {
+		if err := s.contacts.Delete(r.Context(), id); err != nil {
+			respondWithStorageError(w, err, requestID)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	contact, err := s.contacts.Get(r.Context(), id)
+	if err != nil {
+		respondWithStorageError(w, err, requestID)
+		return
+	}
+	if r.Method == http.MethodPatch {
+		if err := json.NewDecoder(r.Body).Decode(contact); err != nil {
+			RespondWithError(w, errors.ErrBadRequest("Invalid JSON format: "+err.Error()).WithRequestID(requestID))
+			return
+		}
+		contact.ID = id
+		if err := s.contacts.Update(r.Context(), contact); err != nil {
+			respondWithStorageError(w, err, requestID)
+			return
+		}
+		if contact, err = s.contacts.Get(r.Context(), id); err != nil {
+			respondWithStorageError(w, err, requestID)
+			return
+		}
+	}
+	RespondWithJSON(w, http.StatusOK, contact)
+}
+
+// handleContactInteractions lists (GET) or logs (POST) a contact's interactions.
+func (s *Server) handleContactInteractions(w http.ResponseWriter, r *http.Request) {
+	requestID := logger.GetRequestID(r.Context())
+	id := r.PathValue("id")
+
+	if r.Method == http.MethodPost {
+		var interaction types.Interaction
+		if err := json.NewDecoder(r.Body).Decode(&interaction); err != nil {
+			RespondWithError(w, errors.ErrBadRequest("Invalid JSON format: "+err.Error()).WithRequestID(requestID))
+			return
+		}
+		interaction.ID, interaction.ContactID = "", id
+		if err := s.contacts.Log(r.Context(), &interaction); err != nil {
+			respondWithStorageError(w, err, requestID)
+			return
+		}
+		RespondWithJSON(w, http.StatusCreated, interaction)
+		return
+	}
+
+	if _, err := s.contacts.Get(r.Context(), id); err != nil {
+		respondWithStorageError(w, err, requestID)
+		return
+	}
+	interactions, err := s.contacts.Interactions(r.Context(), storage.InteractionFilter{ContactID: id})
+	if err != nil {
+		respondWithStorageError(w, err, requestID)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, interactions)
+}
+
+func (s *Server) handleDeleteInteraction(w http.ResponseWriter, r *http.Request) {
+	requestID := logger.GetRequestID(r.Context())
+
+	if err := s.contacts.DeleteInteraction(r.Context(), r.PathValue("id")); err != nil {
+		respondWithStorageError(w, err, requestID)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleApplicationContacts lists (GET) an application's contacts or adds one (POST):
+// a body with just an id links an existing contact, anything else creates a new one.
+func (s *Server) handleApplicationContacts(w http.ResponseWriter, r *http.Request) {
+	requestID := logger.GetRequestID(r.Context())
+	id := r.PathValue("id")
+
+	app, err := s.applications.Get(r.Context(), id)
+	if err != nil {
+		respondWithStorageError(w, err, requestID)
+		return
+	}
+
+	if r.Method == http.MethodPost {
+		var contact types.Contact
+		if err := json.NewDecoder(r.Body).Decode(&contact); err != nil {
+			RespondWithError(w, errors.ErrBadRequest("Invalid JSON format: "+err.Error()).WithRequestID(requestID))
+			return
+		}
+		if err := s.contacts.AddToApplication(r.Context(), app, &contact); err != nil {
+			respondWithStorageError(w, err, requestID)
+			return
+		}
+		slog.Info("Contact linked", "contact_id", contact.ID, "application_id", id, "request_id", requestID)
+		RespondWithJSON(w, http.StatusCreated, contact)
+		return
+	}
+
+	contacts, err := s.contacts.List(r.Context(), storage.ContactFilter{ApplicationID: id})
+	if err != nil {
+		respondWithStorageError(w, err, requestID)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, contacts)
+}
+
+func (s *Server) handleUnlinkContact(w http.ResponseWriter, r *http.Request) {
+	requestID := logger.GetRequestID(r.Context())
+
+	if err := s.contacts.Unlink(r.Context(), r.PathValue("id"), r.PathValue("contact_id")); err != nil {
+		respondWithStorageError(w, err, requestID)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleApplicationInteractions lists every interaction logged about an application,
+// whoever it was with.
+func (s *Server) handleApplicationInteractions(w http.ResponseWriter, r *http.Request) {
+	requestID := logger.GetRequestID(r.Context())
+	id := r.PathValue("id")
+
+	if _, err := s.applications.Get(r.Context(), id); err != nil {
+		respondWithStorageError(w, err, requestID)
+		return
+	}
+	interactions, err := s.contacts.Interactions(r.Context(), storage.InteractionFilter{ApplicationID: id})
+	if err != nil {
+		respondWithStorageError(w, err, requestID)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, interactions)
+}
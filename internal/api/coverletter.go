@@ -0,0 +1,89 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/p-shah256/tracker/internal/llm"
+	"github.com/p-shah256/tracker/internal/render"
+	"github.com/p-shah256/tracker/pkg/errors"
+	"github.com/p-shah256/tracker/pkg/logger"
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+// handleCoverLetter drafts a cover letter for a job description. A /score result can be
+// passed along to skip scoring the resume again.
+func (s *Server) handleCoverLetter(w http.ResponseWriter, r *http.Request) {
+	requestID := logger.GetRequestID(r.Context())
+
+	var req types.CoverLetterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to parse request", "err", err, "request_id", requestID)
+		RespondWithError(w, errors.ErrBadRequest("Invalid JSON format: "+err.Error()).WithRequestID(requestID))
+		return
+	}
+
+	if req.Resume == "" {
+		RespondWithError(w, errors.ErrBadRequest("Resume content is required").WithRequestID(requestID))
+		return
+	}
+
+	opts := llm.CoverLetterOptions{Tone: req.Tone, Length: req.Length}
+	if err := opts.Validate(); err != nil {
+		RespondWithError(w, errors.ErrBadRequest(err.Error()).WithRequestID(requestID))
+		return
+	}
+
+	var format render.Format
+	if f := strings.ToLower(req.Format); f != "" && f != "json" {
+		var err error
+		if format, err = render.ParseFormat(f); err != nil || format == render.FormatHTML {
+			RespondWithError(w, errors.ErrBadRequest("Format must be json, markdown or pdf").WithRequestID(requestID))
+			return
+		}
+	}
+
+	job, apiErr := s.loadJobContext(r, req.JobDescText, req.JobDescURL, req.Resume, req.Scored)
+	if apiErr != nil {
+		RespondWithError(w, apiErr.WithRequestID(requestID))
+		return
+	}
+
+	letter, err := s.llmClient.GenerateCoverLetter(job.skills, job.scored, req.Resume, job.jobDesc, opts)
+	if err != nil {
+		slog.Error("Cover letter generation failed", "err", err, "request_id", requestID)
+		RespondWithError(w, errors.ErrLLMProcessing("Failed to generate cover letter: "+err.Error()).WithRequestID(requestID))
+		return
+	}
+
+	if format == "" {
+		RespondWithJSON(w, http.StatusOK, letter)
+		return
+	}
+	// a download reads as a finished letter, one with claims the resume doesn't back
+	// goes back as JSON so the findings can be seen and fixed
+	if len(letter.Findings) > 0 {
+		slog.Warn("Cover letter has unsupported claims, not rendering it", "findings", len(letter.Findings), "format", format, "request_id", requestID)
+		RespondWithJSON(w, http.StatusUnprocessableEntity, letter)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := render.RenderCoverLetter(&buf, letter, format); err != nil {
+		slog.Error("Cover letter rendering failed", "err", err, "format", format, "request_id", requestID)
+		RespondWithError(w, errors.ErrInternalServer("Failed to render cover letter: "+err.Error()).WithRequestID(requestID))
+		return
+	}
+
+	w.Header().Set("Content-Type", format.ContentType())
+	if format == render.FormatPDF {
+		w.Header().Set("Content-Disposition", `attachment; filename="cover-letter.pdf"`)
+	}
+	w.WriteHeader(http.StatusOK)
+	if _, err := buf.WriteTo(w); err != nil {
+		slog.Error("Failed to write cover letter", "err", err, "request_id", requestID)
+	}
+}
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/p-shah256/tracker/internal/history"
+	"github.com/p-shah256/tracker/internal/storage"
+	"github.com/p-shah256/tracker/pkg/logger"
+)
+
+// handleScoreHistory shows how the score against a job description moved across resume
+// revisions. The job is any /score request ID for it; resume_id narrows it to one resume.
+func (s *Server) handleScoreHistory(w http.ResponseWriter, r *http.Request) {
+	requestID := logger.GetRequestID(r.Context())
+	id := r.PathValue("id")
+
+	job, err := s.store.GetJob(r.Context(), id)
+	if err != nil {
+		respondWithStorageError(w, err, requestID)
+		return
+	}
+	runs, err := s.store.ListScoreRuns(r.Context(), storage.ScoreRunFilter{
+		JobID:    id,
+		ResumeID: r.URL.Query().Get("resume_id"),
+	})
+	if err != nil {
+		respondWithStorageError(w, err, requestID)
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, history.Build(job, runs))
+}
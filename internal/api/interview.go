@@ -0,0 +1,44 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/p-shah256/tracker/pkg/errors"
+	"github.com/p-shah256/tracker/pkg/logger"
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+// handleInterviewPrep turns the job's requirements and the resume's gaps into likely
+// interview questions with answer outlines.
+func (s *Server) handleInterviewPrep(w http.ResponseWriter, r *http.Request) {
+	requestID := logger.GetRequestID(r.Context())
+
+	var req types.InterviewPrepRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to parse request", "err", err, "request_id", requestID)
+		RespondWithError(w, errors.ErrBadRequest("Invalid JSON format: "+err.Error()).WithRequestID(requestID))
+		return
+	}
+
+	if req.Resume == "" {
+		RespondWithError(w, errors.ErrBadRequest("Resume content is required").WithRequestID(requestID))
+		return
+	}
+
+	job, apiErr := s.loadJobContext(r, req.JobDescText, req.JobDescURL, req.Resume, req.Scored)
+	if apiErr != nil {
+		RespondWithError(w, apiErr.WithRequestID(requestID))
+		return
+	}
+
+	prep, err := s.llmClient.PrepareInterview(job.skills, job.scored, req.Resume)
+	if err != nil {
+		slog.Error("Interview prep failed", "err", err, "request_id", requestID)
+		RespondWithError(w, errors.ErrLLMProcessing("Failed to prepare interview questions: "+err.Error()).WithRequestID(requestID))
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, prep)
+}
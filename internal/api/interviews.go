@@ -0,0 +1,88 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/p-shah256/tracker/internal/storage"
+	"github.com/p-shah256/tracker/pkg/errors"
+	"github.com/p-shah256/tracker/pkg/logger"
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+// handleApplicationInterviews lists (GET) or schedules (POST) one application's interviews.
+func (s *Server) handleApplicationInterviews(w http.ResponseWriter, r *http.Request) {
+	requestID := logger.GetRequestID(r.Context())
+	id := r.PathValue("id")
+
+	if r.Method == http.MethodPost {
+		var interview types.Interview
+		if err := json.NewDecoder(r.Body).Decode(&interview); err != nil {
+			RespondWithError(w, errors.ErrBadRequest("Invalid JSON format: "+err.Error()).WithRequestID(requestID))
+			return
+		}
+		interview.ID, interview.ApplicationID = "", id
+		if err := s.applications.AddInterview(r.Context(), &interview); err != nil {
+			respondWithStorageError(w, err, requestID)
+			return
+		}
+		slog.Info("Interview scheduled", "interview_id", interview.ID, "application_id", id, "request_id", requestID)
+		if err := s.reminders.InterviewScheduled(r.Context(), &interview); err != nil {
+			slog.Error("Failed to schedule reminders", "err", err, "interview_id", interview.ID, "request_id", requestID)
+		}
+		RespondWithJSON(w, http.StatusCreated, interview)
+		return
+	}
+
+	if _, err := s.applications.Get(r.Context(), id); err != nil {
+		respondWithStorageError(w, err, requestID)
+		return
+	}
+	interviews, err := s.applications.Interviews(r.Context(), storage.InterviewFilter{ApplicationID: id})
+	if err != nil {
+		respondWithStorageError(w, err, requestID)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, interviews)
+}
+
+// handleInterview edits (PATCH) or cancels (DELETE) an interview. Moving it moves its
+// prep reminder along.
+func (s *Server) handleInterview(w http.ResponseWriter, r *http.Request) {
+	requestID := logger.GetRequestID(r.Context())
+	id := r.PathValue("id")
+
+	if r.Method == http.MethodDelete {
+		if err := s.applications.DeleteInterview(r.Context(), id); err != nil {
+			respondWithStorageError(w, err, requestID)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	interview, err := s.applications.GetInterview(r.Context(), id)
+	if err != nil {
+		respondWithStorageError(w, err, requestID)
+		return
+	}
+	applicationID, startsAt, length := interview.ApplicationID, interview.StartsAt, interview.EndsAt.Sub(interview.StartsAt)
+	if err := json.NewDecoder(r.Body).Decode(interview); err != nil {
+		RespondWithError(w, errors.ErrBadRequest("Invalid JSON format: "+err.Error()).WithRequestID(requestID))
+		return
+	}
+	interview.ID, interview.ApplicationID = id, applicationID
+	// rescheduling without a new end keeps the interview's length
+	if !interview.StartsAt.Equal(startsAt) && interview.EndsAt.Equal(startsAt.Add(length)) {
+		interview.EndsAt = interview.StartsAt.Add(length)
+	}
+	if err := s.applications.UpdateInterview(r.Context(), interview); err != nil {
+		respondWithStorageError(w, err, requestID)
+		return
+	}
+	if err := s.reminders.InterviewScheduled(r.Context(), interview); err != nil {
+		slog.Error("Failed to schedule reminders", "err", err, "interview_id", id, "request_id", requestID)
+	}
+	RespondWithJSON(w, http.StatusOK, interview)
+}
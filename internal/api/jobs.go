@@ -0,0 +1,134 @@
+package api
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/p-shah256/tracker/internal/cleaner"
+	"github.com/p-shah256/tracker/internal/fetch"
+	"github.com/p-shah256/tracker/pkg/errors"
+	"github.com/p-shah256/tracker/pkg/logger"
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+type cleanRequest struct {
+	JobDescText string `json:"jobDescText"`
+	JobDescURL  string `json:"jobDescURL,omitempty"`
+	// Format is "markdown" (default) or "text"
+	Format string `json:"format,omitempty"`
+}
+
+type cleanResponse struct {
+	Format     string            `json:"format"`
+	Text       string            `json:"text"`
+	Site       string            `json:"site,omitempty"`
+	JobPosting *types.JobPosting `json:"job_posting,omitempty"`
+	Blocks     []cleaner.Block   `json:"blocks"`
+}
+
+// handleCleanJobDescription shows what the LLM will be given for a job description.
+func (s *Server) handleCleanJobDescription(w http.ResponseWriter, r *http.Request) {
+	requestID := logger.GetRequestID(r.Context())
+
+	var req cleanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to parse request", "err", err, "request_id", requestID)
+		RespondWithError(w, errors.ErrBadRequest("Invalid JSON format: "+err.Error()).WithRequestID(requestID))
+		return
+	}
+
+	c := s.cleaner.WithMode(cleaner.ModeMarkdown)
+	switch req.Format {
+	case "", "markdown":
+		req.Format = "markdown"
+	case "text":
+		c = s.cleaner.WithMode(cleaner.ModeText)
+	default:
+		RespondWithError(w, errors.ErrBadRequest("Format must be markdown or text").WithRequestID(requestID))
+		return
+	}
+
+	jobDesc, pageURL, apiErr := s.loadJobDescription(r, req.JobDescText, req.JobDescURL)
+	if apiErr != nil {
+		RespondWithError(w, apiErr.WithRequestID(requestID))
+		return
+	}
+
+	result := c.CleanPage(jobDesc, pageURL)
+	RespondWithJSON(w, http.StatusOK, cleanResponse{
+		Format:     req.Format,
+		Text:       result.Text,
+		Site:       result.Site,
+		JobPosting: result.Posting,
+		Blocks:     result.Blocks,
+	})
+}
+
+// loadJobDescription returns the pasted job description, or fetches it when only a URL was given.
+func (s *Server) loadJobDescription(r *http.Request, text, url string) (string, string, *errors.ApiError) {
+	if text != "" && url != "" {
+		return "", "", errors.ErrBadRequest("Provide either jobDescText or jobDescURL, not both")
+	}
+	if text == "" && url == "" {
+		return "", "", errors.ErrBadRequest("Job description is required")
+	}
+	if text != "" {
+		return text, "", nil
+	}
+
+	page, err := s.fetcher.Fetch(r.Context(), url)
+	if err != nil {
+		slog.Error("Job description fetch failed",
+			"err", err,
+			"url", url,
+			"request_id", logger.GetRequestID(r.Context()),
+		)
+		return "", "", fetchError(err)
+	}
+	return page.Body, page.URL, nil
+}
+
+// jobContext is what the tools built on top of /score need: the job description, its
+// extracted skills and a scored resume. A scored resume from an earlier /score call is reused.
+type jobContext struct {
+	jobDesc string
+	skills  *types.ExtractedSkills
+	scored  *types.ScoredResume
+}
+
+func (s *Server) loadJobContext(r *http.Request, text, url, resume string, scored *types.ScoredResume) (*jobContext, *errors.ApiError) {
+	requestID := logger.GetRequestID(r.Context())
+
+	jobDesc, pageURL, apiErr := s.loadJobDescription(r, text, url)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	skills, err := s.llmClient.ExtractSkillsFromPage(jobDesc, pageURL)
+	if err != nil {
+		slog.Error("Skills extraction failed", "err", err, "request_id", requestID)
+		return nil, errors.ErrLLMProcessing("Failed to extract skills: " + err.Error())
+	}
+
+	if scored == nil {
+		if scored, err = s.llmClient.ScoreResume(skills, resume); err != nil {
+			slog.Error("Resume scoring failed", "err", err, "request_id", requestID)
+			return nil, errors.ErrLLMProcessing("Failed to score resume: " + err.Error())
+		}
+	}
+	return &jobContext{jobDesc: jobDesc, skills: skills, scored: scored}, nil
+}
+
+// fetchError maps problems with the URL itself to 400 and everything else to 502.
+func fetchError(err error) *errors.ApiError {
+	switch {
+	case stderrors.Is(err, fetch.ErrInvalidURL),
+		stderrors.Is(err, fetch.ErrBlockedAddress),
+		stderrors.Is(err, fetch.ErrUnsupported),
+		stderrors.Is(err, fetch.ErrTooLarge):
+		return errors.ErrBadRequest("Cannot use job description URL: " + err.Error())
+	}
+	return errors.ErrBadGateway("Failed to fetch job description: " + err.Error())
+}
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/p-shah256/tracker/internal/lint"
+	"github.com/p-shah256/tracker/pkg/errors"
+	"github.com/p-shah256/tracker/pkg/logger"
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+// handleLint checks the bullets of one role for weak writing, no LLM involved.
+func (s *Server) handleLint(w http.ResponseWriter, r *http.Request) {
+	requestID := logger.GetRequestID(r.Context())
+
+	var req types.LintRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to parse request", "err", err, "request_id", requestID)
+		RespondWithError(w, errors.ErrBadRequest("Invalid JSON format: "+err.Error()).WithRequestID(requestID))
+		return
+	}
+	if len(req.Bullets) == 0 {
+		RespondWithError(w, errors.ErrBadRequest("At least one bullet is required").WithRequestID(requestID))
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, lint.Lint(req.Bullets))
+}
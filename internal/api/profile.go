@@ -0,0 +1,58 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/p-shah256/tracker/internal/llm"
+	"github.com/p-shah256/tracker/pkg/errors"
+	"github.com/p-shah256/tracker/pkg/logger"
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+// handleTailorProfile rewrites the headline and summary for a job and reorders the
+// skills list by what the job asks for.
+func (s *Server) handleTailorProfile(w http.ResponseWriter, r *http.Request) {
+	requestID := logger.GetRequestID(r.Context())
+
+	var req types.ProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to parse request", "err", err, "request_id", requestID)
+		RespondWithError(w, errors.ErrBadRequest("Invalid JSON format: "+err.Error()).WithRequestID(requestID))
+		return
+	}
+
+	if req.Resume == "" {
+		RespondWithError(w, errors.ErrBadRequest("Resume content is required").WithRequestID(requestID))
+		return
+	}
+	if !llm.ValidTransformMode(req.Mode) {
+		RespondWithError(w, errors.ErrBadRequest(fmt.Sprintf("Mode must be %q or %q", llm.ModeTruthful, llm.ModeAggressive)).WithRequestID(requestID))
+		return
+	}
+
+	jobDesc, pageURL, apiErr := s.loadJobDescription(r, req.JobDescText, req.JobDescURL)
+	if apiErr != nil {
+		RespondWithError(w, apiErr.WithRequestID(requestID))
+		return
+	}
+
+	skills, err := s.llmClient.ExtractSkillsFromPage(jobDesc, pageURL)
+	if err != nil {
+		slog.Error("Skills extraction failed", "err", err, "request_id", requestID)
+		RespondWithError(w, errors.ErrLLMProcessing("Failed to extract skills: "+err.Error()).WithRequestID(requestID))
+		return
+	}
+
+	profile, err := s.llmClient.TailorProfile(skills, &req)
+	if err != nil {
+		slog.Error("Profile tailoring failed", "err", err, "request_id", requestID)
+		RespondWithError(w, errors.ErrLLMProcessing("Failed to tailor profile: "+err.Error()).WithRequestID(requestID))
+		return
+	}
+	profile.Skills = s.matcher.RankSkills(skills, profile.Skills.Original)
+
+	RespondWithJSON(w, http.StatusOK, profile)
+}
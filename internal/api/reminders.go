@@ -0,0 +1,86 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/p-shah256/tracker/internal/storage"
+	"github.com/p-shah256/tracker/pkg/errors"
+	"github.com/p-shah256/tracker/pkg/logger"
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+// handleReminders lists reminders across all applications, ?pending=true for the ones
+// still to fire and ?due_before= to look ahead.
+func (s *Server) handleReminders(w http.ResponseWriter, r *http.Request) {
+	requestID := logger.GetRequestID(r.Context())
+
+	var filter storage.ReminderFilter
+	q := r.URL.Query()
+	if v := q.Get("pending"); v != "" {
+		pending, err := strconv.ParseBool(v)
+		if err != nil {
+			RespondWithError(w, errors.ErrBadRequest("pending must be true or false").WithRequestID(requestID))
+			return
+		}
+		filter.Pending = pending
+	}
+	if v := q.Get("due_before"); v != "" {
+		t, err := parseTime(v)
+		if err != nil {
+			RespondWithError(w, errors.ErrBadRequest("due_before must be a date (2006-01-02) or RFC 3339 time").WithRequestID(requestID))
+			return
+		}
+		filter.DueBefore = t
+	}
+
+	reminders, err := s.reminders.List(r.Context(), filter)
+	if err != nil {
+		respondWithStorageError(w, err, requestID)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, reminders)
+}
+
+// handleApplicationReminders lists (GET) or adds (POST) one application's reminders.
+func (s *Server) handleApplicationReminders(w http.ResponseWriter, r *http.Request) {
+	requestID := logger.GetRequestID(r.Context())
+	id := r.PathValue("id")
+
+	if r.Method == http.MethodPost {
+		var req types.ReminderRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			RespondWithError(w, errors.ErrBadRequest("Invalid JSON format: "+err.Error()).WithRequestID(requestID))
+			return
+		}
+		reminder, err := s.reminders.Schedule(r.Context(), id, req)
+		if err != nil {
+			respondWithStorageError(w, err, requestID)
+			return
+		}
+		RespondWithJSON(w, http.StatusCreated, reminder)
+		return
+	}
+
+	if _, err := s.applications.Get(r.Context(), id); err != nil {
+		respondWithStorageError(w, err, requestID)
+		return
+	}
+	reminders, err := s.reminders.List(r.Context(), storage.ReminderFilter{ApplicationID: id})
+	if err != nil {
+		respondWithStorageError(w, err, requestID)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, reminders)
+}
+
+func (s *Server) handleDeleteReminder(w http.ResponseWriter, r *http.Request) {
+	requestID := logger.GetRequestID(r.Context())
+
+	if err := s.reminders.Delete(r.Context(), r.PathValue("id")); err != nil {
+		respondWithStorageError(w, err, requestID)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+
+	"github.com/p-shah256/tracker/internal/render"
+	"github.com/p-shah256/tracker/internal/storage"
+	"github.com/p-shah256/tracker/pkg/errors"
+	"github.com/p-shah256/tracker/pkg/logger"
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+func (s *Server) handleCreateResume(w http.ResponseWriter, r *http.Request) {
+	requestID := logger.GetRequestID(r.Context())
+
+	var req types.Resume
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to parse resume", "err", err, "request_id", requestID)
+		RespondWithError(w, errors.ErrBadRequest("Invalid JSON format: "+err.Error()).WithRequestID(requestID))
+		return
+	}
+
+	if req.Name == "" {
+		RespondWithError(w, errors.ErrBadRequest("Resume name is required").WithRequestID(requestID))
+		return
+	}
+
+	id, err := s.store.SaveResume(r.Context(), req)
+	if err != nil {
+		slog.Error("Failed to save resume", "err", err, "request_id", requestID)
+		RespondWithError(w, errors.ErrInternalServer("Failed to save resume").WithRequestID(requestID))
+		return
+	}
+	slog.Info("Resume saved", "resume_id", id, "sections", len(req.Sections), "request_id", requestID)
+
+	RespondWithJSON(w, http.StatusCreated, map[string]string{"id": id})
+}
+
+// handleChooseBullets records which transformed bullets should replace the originals on render.
+func (s *Server) handleChooseBullets(w http.ResponseWriter, r *http.Request) {
+	requestID := logger.GetRequestID(r.Context())
+	id := r.PathValue("id")
+
+	var items []types.TransformedItem
+	if err := json.NewDecoder(r.Body).Decode(&items); err != nil {
+		slog.Error("Failed to parse chosen bullets", "err", err, "request_id", requestID)
+		RespondWithError(w, errors.ErrBadRequest("Invalid JSON format: "+err.Error()).WithRequestID(requestID))
+		return
+	}
+
+	if err := s.store.ChooseBullets(r.Context(), id, items); err != nil {
+		respondWithResumeError(w, err, requestID)
+		return
+	}
+
+	// accepting bullets makes a new version, so what was sent where can be traced later
+	res, _, err := s.store.GetResume(r.Context(), id)
+	if err != nil {
+		respondWithResumeError(w, err, requestID)
+		return
+	}
+	version := &types.ResumeVersion{
+		ResumeID: id,
+		Note:     fmt.Sprintf("chose %d transformed bullets", len(items)),
+		Resume:   render.ApplyBullets(res, items),
+	}
+	if err := s.store.CreateResumeVersion(r.Context(), version); err != nil {
+		slog.Error("Failed to save resume version", "err", err, "resume_id", id, "request_id", requestID)
+		RespondWithError(w, errors.ErrInternalServer("Failed to save resume version").WithRequestID(requestID))
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, map[string]any{
+		"id":         id,
+		"chosen":     len(items),
+		"version":    version.Number,
+		"version_id": version.ID,
+	})
+}
+
+func (s *Server) handleRenderResume(w http.ResponseWriter, r *http.Request) {
+	requestID := logger.GetRequestID(r.Context())
+	id := r.PathValue("id")
+
+	format, err := render.ParseFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		RespondWithError(w, errors.ErrBadRequest(err.Error()).WithRequestID(requestID))
+		return
+	}
+
+	theme := r.URL.Query().Get("theme")
+	if theme != "" && !slices.Contains(render.Themes(), theme) {
+		RespondWithError(w, errors.ErrBadRequest(fmt.Sprintf("Unknown theme %q, available: %v", theme, render.Themes())).WithRequestID(requestID))
+		return
+	}
+
+	var tailored types.Resume
+	if number := r.URL.Query().Get("version"); number != "" {
+		versions, err := s.store.ListResumeVersions(r.Context(), id)
+		if err != nil {
+			respondWithResumeError(w, err, requestID)
+			return
+		}
+		version, apiErr := findVersion(versions, number)
+		if apiErr != nil {
+			RespondWithError(w, apiErr.WithRequestID(requestID))
+			return
+		}
+		tailored = version.Resume
+	} else {
+		res, chosen, err := s.store.GetResume(r.Context(), id)
+		if err != nil {
+			respondWithResumeError(w, err, requestID)
+			return
+		}
+		tailored = render.ApplyBullets(res, chosen)
+	}
+
+	// render into a buffer first so a failure can still be reported as JSON
+	var buf bytes.Buffer
+	if err := render.Render(&buf, &tailored, format, theme); err != nil {
+		slog.Error("Resume rendering failed", "err", err, "resume_id", id, "format", format, "request_id", requestID)
+		RespondWithError(w, errors.ErrInternalServer("Failed to render resume: "+err.Error()).WithRequestID(requestID))
+		return
+	}
+
+	w.Header().Set("Content-Type", format.ContentType())
+	if format == render.FormatPDF {
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="resume-%s.%s"`, id, format.Extension()))
+	}
+	w.WriteHeader(http.StatusOK)
+	if _, err := buf.WriteTo(w); err != nil {
+		slog.Error("Failed to write rendered resume", "err", err, "request_id", requestID)
+	}
+}
+
+func respondWithResumeError(w http.ResponseWriter, err error, requestID string) {
+	if stderrors.Is(err, storage.ErrNotFound) {
+		RespondWithError(w, errors.ErrNotFound(err.Error()).WithRequestID(requestID))
+		return
+	}
+	RespondWithError(w, errors.ErrInternalServer(err.Error()).WithRequestID(requestID))
+}
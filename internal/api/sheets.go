@@ -0,0 +1,109 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/p-shah256/tracker/internal/sheets"
+	"github.com/p-shah256/tracker/pkg/errors"
+	"github.com/p-shah256/tracker/pkg/logger"
+)
+
+// maxImportSize bytes of CSV are read at most
+const maxImportSize = 10 << 20
+
+// handleImportApplications creates applications from a CSV spreadsheet, sent as the body
+// or as the "file" field of a form. ?map=company=Employer maps a field to a column (repeat
+// for more), ?user_id= owns rows without a user_id column and ?dry_run=true only checks.
+// Bad rows are reported and skipped, the rest are imported.
+func (s *Server) handleImportApplications(w http.ResponseWriter, r *http.Request) {
+	requestID := logger.GetRequestID(r.Context())
+	q := r.URL.Query()
+
+	mapping, err := sheets.ParseMapping(q["map"])
+	if err != nil {
+		RespondWithError(w, errors.ErrBadRequest(err.Error()).WithRequestID(requestID))
+		return
+	}
+	opts := sheets.ImportOptions{Mapping: mapping, UserID: strings.TrimSpace(q.Get("user_id"))}
+	if v := q.Get("dry_run"); v != "" {
+		if opts.DryRun, err = strconv.ParseBool(v); err != nil {
+			RespondWithError(w, errors.ErrBadRequest("dry_run must be true or false").WithRequestID(requestID))
+			return
+		}
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+	var body io.Reader = r.Body
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		if err := r.ParseMultipartForm(maxImportSize); err != nil {
+			RespondWithError(w, errors.ErrBadRequest("Invalid form data: "+err.Error()).WithRequestID(requestID))
+			return
+		}
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			RespondWithError(w, errors.ErrBadRequest("Failed to get file: "+err.Error()).WithRequestID(requestID))
+			return
+		}
+		defer file.Close()
+		body = file
+	}
+
+	result, err := sheets.Import(r.Context(), s.applications, body, opts)
+	if err != nil {
+		if result != nil && result.Imported > 0 {
+			slog.Warn("Import stopped partway", "err", err, "imported", result.Imported, "request_id", requestID)
+			err = fmt.Errorf("%w (the %d applications before it were imported)", err, result.Imported)
+		}
+		respondWithStorageError(w, err, requestID)
+		return
+	}
+	slog.Info("Applications imported", "rows", result.Rows, "imported", result.Imported, "errors", len(result.Errors),
+		"dry_run", result.DryRun, "request_id", requestID)
+	status := http.StatusOK
+	if result.Imported > 0 {
+		status = http.StatusCreated
+	}
+	RespondWithJSON(w, status, result)
+}
+
+// handleExportApplications downloads applications as ?format=csv (the default) or xlsx,
+// filtered like GET /applications.
+func (s *Server) handleExportApplications(w http.ResponseWriter, r *http.Request) {
+	requestID := logger.GetRequestID(r.Context())
+
+	format, err := sheets.ParseFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		RespondWithError(w, errors.ErrBadRequest(err.Error()).WithRequestID(requestID))
+		return
+	}
+	filter, err := applicationFilter(r)
+	if err != nil {
+		RespondWithError(w, errors.ErrBadRequest(err.Error()).WithRequestID(requestID))
+		return
+	}
+	filter.WithHistory = true
+	apps, err := s.applications.List(r.Context(), filter)
+	if err != nil {
+		respondWithStorageError(w, err, requestID)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := sheets.Export(&buf, apps, format); err != nil {
+		slog.Error("Failed to export applications", "err", err, "format", format, "request_id", requestID)
+		RespondWithError(w, errors.ErrInternalServer("Failed to export applications: "+err.Error()).WithRequestID(requestID))
+		return
+	}
+	filename := "applications-" + time.Now().Format(time.DateOnly) + "." + string(format)
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
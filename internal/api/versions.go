@@ -0,0 +1,130 @@
+package api
+
+import (
+	"bytes"
+	stderrors "errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/p-shah256/tracker/internal/diff"
+	"github.com/p-shah256/tracker/internal/render"
+	"github.com/p-shah256/tracker/internal/storage"
+	"github.com/p-shah256/tracker/pkg/errors"
+	"github.com/p-shah256/tracker/pkg/logger"
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+// handleResumeVersions lists every version of a resume with where it was sent and how it scored.
+func (s *Server) handleResumeVersions(w http.ResponseWriter, r *http.Request) {
+	requestID := logger.GetRequestID(r.Context())
+
+	versions, err := s.store.ListResumeVersions(r.Context(), r.PathValue("id"))
+	if err != nil {
+		respondWithResumeError(w, err, requestID)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, versions)
+}
+
+// handleResumeDiff diffs two versions of a resume word by word. to defaults to the latest
+// version and from to the parent of to.
+func (s *Server) handleResumeDiff(w http.ResponseWriter, r *http.Request) {
+	requestID := logger.GetRequestID(r.Context())
+	id := r.PathValue("id")
+
+	versions, err := s.store.ListResumeVersions(r.Context(), id)
+	if err != nil {
+		respondWithResumeError(w, err, requestID)
+		return
+	}
+	if len(versions) == 0 {
+		RespondWithError(w, errors.ErrNotFound("Resume "+id+" has no versions").WithRequestID(requestID))
+		return
+	}
+
+	to, apiErr := findVersion(versions, r.URL.Query().Get("to"))
+	if apiErr != nil {
+		RespondWithError(w, apiErr.WithRequestID(requestID))
+		return
+	}
+	from := &types.ResumeVersion{}
+	if r.URL.Query().Get("from") != "" {
+		from, apiErr = findVersion(versions, r.URL.Query().Get("from"))
+		if apiErr != nil {
+			RespondWithError(w, apiErr.WithRequestID(requestID))
+			return
+		}
+	} else {
+		// the first version is diffed against nothing, so it shows up as all inserts
+		for i := range versions {
+			if versions[i].ID == to.ParentID {
+				from = &versions[i]
+			}
+		}
+	}
+
+	var before, after bytes.Buffer
+	if from.ID != "" {
+		if err := render.Markdown(&before, &from.Resume); err != nil {
+			slog.Error("Failed to render resume version", "err", err, "version_id", from.ID, "request_id", requestID)
+			RespondWithError(w, errors.ErrInternalServer("Failed to render resume version").WithRequestID(requestID))
+			return
+		}
+	}
+	if err := render.Markdown(&after, &to.Resume); err != nil {
+		slog.Error("Failed to render resume version", "err", err, "version_id", to.ID, "request_id", requestID)
+		RespondWithError(w, errors.ErrInternalServer("Failed to render resume version").WithRequestID(requestID))
+		return
+	}
+
+	ops := diff.Words(before.String(), after.String())
+	added, removed := diff.Count(ops)
+	RespondWithJSON(w, http.StatusOK, types.ResumeDiff{
+		ResumeID: id,
+		From:     from.Number,
+		To:       to.Number,
+		Added:    added,
+		Removed:  removed,
+		Ops:      ops,
+	})
+}
+
+// findVersion picks a version by number, empty means the latest.
+func findVersion(versions []types.ResumeVersion, number string) (*types.ResumeVersion, *errors.ApiError) {
+	if number == "" {
+		return &versions[len(versions)-1], nil
+	}
+	n, err := strconv.Atoi(number)
+	if err != nil {
+		return nil, errors.ErrBadRequest(fmt.Sprintf("Invalid version number %q", number))
+	}
+	for i := range versions {
+		if versions[i].Number == n {
+			return &versions[i], nil
+		}
+	}
+	return nil, errors.ErrNotFound(fmt.Sprintf("Resume has no version %d", n))
+}
+
+// loadResumeVersion checks the version a /score request names and, when no resume text
+// was sent, scores the version's Markdown.
+func (s *Server) loadResumeVersion(r *http.Request, req *types.OptimizeRequest) *errors.ApiError {
+	version, err := s.store.GetResumeVersion(r.Context(), req.ResumeVersionID)
+	if stderrors.Is(err, storage.ErrNotFound) {
+		return errors.ErrBadRequest("Unknown resume version " + req.ResumeVersionID)
+	}
+	if err != nil {
+		return errors.ErrInternalServer("Failed to load resume version: " + err.Error())
+	}
+	if req.Resume != "" {
+		return nil
+	}
+	var buf bytes.Buffer
+	if err := render.Markdown(&buf, &version.Resume); err != nil {
+		return errors.ErrInternalServer("Failed to render resume version: " + err.Error())
+	}
+	req.Resume = buf.String()
+	return nil
+}
@@ -0,0 +1,459 @@
+package applications
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/p-shah256/tracker/internal/cleaner"
+	"github.com/p-shah256/tracker/internal/dedup"
+	"github.com/p-shah256/tracker/internal/storage"
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+var (
+	ErrInvalid           = errors.New("invalid application")
+	ErrInvalidTransition = errors.New("invalid status change")
+)
+
+// pipeline is the order an application normally moves through. It can skip ahead
+// (straight from applied to interviewing) but never go back.
+var pipeline = []types.ApplicationStatus{
+	types.StatusSaved,
+	types.StatusApplied,
+	types.StatusScreening,
+	types.StatusInterviewing,
+	types.StatusOffer,
+}
+
+// closed statuses end the pipeline, they can be reached from any open status.
+var closed = []types.ApplicationStatus{
+	types.StatusRejected,
+	types.StatusWithdrawn,
+}
+
+// companySizes bucket companies by headcount, roughly <50, <200, <1000, <10000 and more.
+var companySizes = []string{"startup", "small", "medium", "large", "enterprise"}
+
+func CompanySizes() []string {
+	return slices.Clone(companySizes)
+}
+
+func Statuses() []types.ApplicationStatus {
+	return slices.Concat(pipeline, closed)
+}
+
+func ParseStatus(s string) (types.ApplicationStatus, error) {
+	status := types.ApplicationStatus(strings.ToLower(strings.TrimSpace(s)))
+	if !slices.Contains(Statuses(), status) {
+		return "", fmt.Errorf("%w: unknown status %q", ErrInvalid, s)
+	}
+	return status, nil
+}
+
+// Closed reports whether the application is out of the pipeline for good.
+func Closed(status types.ApplicationStatus) bool {
+	return slices.Contains(closed, status)
+}
+
+// CanTransition allows moving forward through the pipeline and closing an open
+// application. An offer can still be withdrawn from (declined) or rejected (rescinded).
+func CanTransition(from, to types.ApplicationStatus) error {
+	switch {
+	case from == to:
+		return fmt.Errorf("%w: application is already %s", ErrInvalidTransition, to)
+	case Closed(from):
+		return fmt.Errorf("%w: application is %s, it can't move to %s", ErrInvalidTransition, from, to)
+	case Closed(to):
+		return nil
+	case slices.Index(pipeline, to) < slices.Index(pipeline, from):
+		return fmt.Errorf("%w: %s comes before %s", ErrInvalidTransition, to, from)
+	}
+	return nil
+}
+
+// Service is the application tracker on top of the repository: it validates input,
+// fills in details from the /score run an application came from, and guards status changes.
+type Service struct {
+	repo    storage.Repository
+	cleaner *cleaner.Cleaner
+	// signatures caches the MinHash of each job description by job ID, jobs don't change
+	signatures sync.Map
+}
+
+func NewService(repo storage.Repository) *Service {
+	return &Service{repo: repo, cleaner: cleaner.NewCleaner()}
+}
+
+func (s *Service) Create(ctx context.Context, app *types.Application) error {
+	if err := s.Validate(ctx, app); err != nil {
+		return err
+	}
+	if err := s.repo.CreateApplication(ctx, app); err != nil {
+		return err
+	}
+
+	// duplicates are a warning, the application is tracked either way
+	duplicates, err := s.Duplicates(ctx, app)
+	if err != nil {
+		slog.Warn("Duplicate check failed", "err", err, "application_id", app.ID)
+	}
+	app.Duplicates = duplicates
+	return nil
+}
+
+// Validate fills in and checks a new application the way Create does, without saving it.
+func (s *Service) Validate(ctx context.Context, app *types.Application) error {
+	if app.Status == "" {
+		app.Status = types.StatusSaved
+	}
+	if _, err := ParseStatus(string(app.Status)); err != nil {
+		return err
+	}
+	if err := s.fillFromScore(ctx, app); err != nil {
+		return err
+	}
+	return validate(app)
+}
+
+func (s *Service) Get(ctx context.Context, id string) (*types.Application, error) {
+	return s.repo.GetApplication(ctx, id)
+}
+
+func (s *Service) List(ctx context.Context, filter storage.ApplicationFilter) ([]types.Application, error) {
+	return s.repo.ListApplications(ctx, filter)
+}
+
+// Update replaces the editable fields of an application, status is left alone.
+func (s *Service) Update(ctx context.Context, app *types.Application) error {
+	existing, err := s.repo.GetApplication(ctx, app.ID)
+	if err != nil {
+		return err
+	}
+	if err := s.fillFromScore(ctx, app); err != nil {
+		return err
+	}
+	if err := validate(app); err != nil {
+		return err
+	}
+	app.Status = existing.Status
+	app.CreatedAt = existing.CreatedAt
+	if err := s.repo.UpdateApplication(ctx, app); err != nil {
+		return err
+	}
+	app.History = existing.History
+	return nil
+}
+
+func (s *Service) Delete(ctx context.Context, id string) error {
+	return s.repo.DeleteApplication(ctx, id)
+}
+
+// SetStatus moves the application along the pipeline. at backdates the change, zero means now.
+func (s *Service) SetStatus(ctx context.Context, id string, to types.ApplicationStatus, note string, at time.Time) (*types.Application, error) {
+	if _, err := ParseStatus(string(to)); err != nil {
+		return nil, err
+	}
+	app, err := s.repo.GetApplication(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := CanTransition(app.Status, to); err != nil {
+		return nil, err
+	}
+	if at.IsZero() {
+		at = time.Now()
+	}
+	if len(app.History) > 0 && at.Before(app.History[len(app.History)-1].At) {
+		return nil, fmt.Errorf("%w: %s is before the last status change", ErrInvalidTransition, at.Format(time.RFC3339))
+	}
+
+	if err := s.repo.SetStatus(ctx, id, app.Status, types.StatusChange{To: to, At: at, Note: note}); err != nil {
+		return nil, err
+	}
+	return s.repo.GetApplication(ctx, id)
+}
+
+// Duplicates finds the user's other tracked applications that look like the same posting
+// as app: the same URL or /score job, a near-identical job description, or the same
+// company and title.
+func (s *Service) Duplicates(ctx context.Context, app *types.Application) ([]types.DuplicateMatch, error) {
+	posting, err := s.posting(ctx, app)
+	if err != nil {
+		return nil, err
+	}
+	others, err := s.repo.ListApplications(ctx, storage.ApplicationFilter{UserID: app.UserID})
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []types.DuplicateMatch{}
+	for _, other := range others {
+		// an empty user ID lists everyone's, those only match applications without a user too
+		if other.ID == app.ID || other.UserID != app.UserID {
+			continue
+		}
+		candidate, err := s.posting(ctx, &other)
+		if err != nil {
+			return nil, err
+		}
+		m := dedup.Compare(posting, candidate)
+		sameSource := (app.URL != "" && app.URL == other.URL) || (app.JobID != "" && app.JobID == other.JobID)
+		if !m.Duplicate && !sameSource {
+			continue
+		}
+		matches = append(matches, types.DuplicateMatch{
+			ApplicationID: other.ID,
+			Company:       other.Company,
+			Position:      other.Position,
+			Status:        other.Status,
+			URL:           other.URL,
+			Similarity:    m.Similarity,
+			SameCompany:   m.SameCompany,
+			SameTitle:     m.SameTitle,
+		})
+	}
+	slices.SortStableFunc(matches, func(a, b types.DuplicateMatch) int {
+		return cmp.Compare(b.Similarity, a.Similarity)
+	})
+	return matches, nil
+}
+
+// posting is what an application is compared on, its job description signature comes
+// from the linked /score job when there is one.
+func (s *Service) posting(ctx context.Context, app *types.Application) (dedup.Posting, error) {
+	posting := dedup.Posting{Company: app.Company, Title: app.Position}
+	if app.JobID == "" {
+		return posting, nil
+	}
+	if sig, ok := s.signatures.Load(app.JobID); ok {
+		posting.Signature = sig.(dedup.Signature)
+		return posting, nil
+	}
+
+	job, err := s.repo.GetJob(ctx, app.JobID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return posting, nil
+	}
+	if err != nil {
+		return posting, err
+	}
+	posting.Signature = dedup.NewSignature(s.cleaner.CleanHTML(job.Description))
+	s.signatures.Store(app.JobID, posting.Signature)
+	return posting, nil
+}
+
+// Merge folds the duplicate into the application with id and deletes it. Fields the
+// application is missing are taken from the duplicate, notes are combined, the status
+// is whichever of the two changed last, and the duplicate's status history moves over.
+func (s *Service) Merge(ctx context.Context, id, duplicateID string) (*types.Application, error) {
+	if id == duplicateID {
+		return nil, fmt.Errorf("%w: can't merge an application into itself", ErrInvalid)
+	}
+	app, err := s.repo.GetApplication(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	dup, err := s.repo.GetApplication(ctx, duplicateID)
+	if err != nil {
+		return nil, err
+	}
+	if app.UserID != dup.UserID {
+		return nil, fmt.Errorf("%w: applications %s and %s belong to different users", ErrInvalid, app.ID, dup.ID)
+	}
+
+	app.Level = orDefault(app.Level, dup.Level)
+	app.CompanySize = orDefault(app.CompanySize, dup.CompanySize)
+	app.URL = orDefault(app.URL, dup.URL)
+	// linked records are taken as a set, a score run only makes sense with its job and a
+	// version with its resume
+	if app.JobID == "" || app.JobID == dup.JobID {
+		app.JobID = dup.JobID
+		app.ScoreRunID = orDefault(app.ScoreRunID, dup.ScoreRunID)
+	}
+	if app.ResumeID == "" || app.ResumeID == dup.ResumeID {
+		app.ResumeID = dup.ResumeID
+		app.ResumeVersionID = orDefault(app.ResumeVersionID, dup.ResumeVersionID)
+	}
+	if app.Score == nil {
+		app.Score = dup.Score
+	}
+	if app.Deadline == nil {
+		app.Deadline = dup.Deadline
+	}
+	if dup.Notes != "" && !strings.Contains(app.Notes, dup.Notes) {
+		app.Notes = strings.TrimSpace(app.Notes + "\n\n" + dup.Notes)
+	}
+	if dup.CreatedAt.Before(app.CreatedAt) {
+		app.CreatedAt = dup.CreatedAt
+	}
+	app.Status = mergedStatus(app, dup)
+
+	if err := s.repo.MergeApplications(ctx, app, dup.ID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetApplication(ctx, id)
+}
+
+// AddInterview schedules an interview round for an application.
+func (s *Service) AddInterview(ctx context.Context, i *types.Interview) error {
+	if err := validateInterview(i); err != nil {
+		return err
+	}
+	return s.repo.CreateInterview(ctx, i)
+}
+
+func (s *Service) GetInterview(ctx context.Context, id string) (*types.Interview, error) {
+	return s.repo.GetInterview(ctx, id)
+}
+
+func (s *Service) Interviews(ctx context.Context, filter storage.InterviewFilter) ([]types.Interview, error) {
+	return s.repo.ListInterviews(ctx, filter)
+}
+
+// UpdateInterview reschedules or edits an interview, it stays with its application.
+func (s *Service) UpdateInterview(ctx context.Context, i *types.Interview) error {
+	if err := validateInterview(i); err != nil {
+		return err
+	}
+	return s.repo.UpdateInterview(ctx, i)
+}
+
+func (s *Service) DeleteInterview(ctx context.Context, id string) error {
+	return s.repo.DeleteInterview(ctx, id)
+}
+
+func validateInterview(i *types.Interview) error {
+	i.Title = strings.TrimSpace(i.Title)
+	if i.Title == "" {
+		i.Title = "Interview"
+	}
+	if i.StartsAt.IsZero() {
+		return fmt.Errorf("%w: starts_at is required", ErrInvalid)
+	}
+	if i.EndsAt.IsZero() {
+		i.EndsAt = i.StartsAt.Add(time.Hour)
+	}
+	if !i.EndsAt.After(i.StartsAt) {
+		return fmt.Errorf("%w: interview must end after it starts", ErrInvalid)
+	}
+	return nil
+}
+
+// mergedStatus keeps whichever of two applications is further along the pipeline, so a
+// merge never moves one backwards. A closed status wins only when it's the more recent.
+func mergedStatus(app, dup *types.Application) types.ApplicationStatus {
+	if Closed(app.Status) || Closed(dup.Status) {
+		if statusSince(dup).After(statusSince(app)) {
+			return dup.Status
+		}
+		return app.Status
+	}
+	if slices.Index(pipeline, dup.Status) > slices.Index(pipeline, app.Status) {
+		return dup.Status
+	}
+	return app.Status
+}
+
+// statusSince is when the application got its current status, creation included.
+func statusSince(app *types.Application) time.Time {
+	if len(app.History) > 0 {
+		return app.History[len(app.History)-1].At
+	}
+	return app.CreatedAt
+}
+
+// fillFromScore copies company, position, level, URL and score from the linked /score
+// run for anything the caller left empty, and checks the linked resume and version exist.
+func (s *Service) fillFromScore(ctx context.Context, app *types.Application) error {
+	if app.ScoreRunID != "" {
+		run, err := s.repo.GetScoreRun(ctx, app.ScoreRunID)
+		if err != nil {
+			return linkError(err)
+		}
+		if app.JobID == "" {
+			app.JobID = run.JobID
+		}
+		if app.ResumeVersionID == "" {
+			app.ResumeVersionID = run.ResumeVersionID
+		}
+		if app.Score == nil && run.Result != nil {
+			score := run.Result.OverallScore
+			app.Score = &score
+		}
+	}
+	if app.ResumeVersionID != "" {
+		version, err := s.repo.GetResumeVersion(ctx, app.ResumeVersionID)
+		if err != nil {
+			return linkError(err)
+		}
+		if app.ResumeID == "" {
+			app.ResumeID = version.ResumeID
+		}
+		if app.ResumeID != version.ResumeID {
+			return fmt.Errorf("%w: resume version %s belongs to resume %s, not %s", ErrInvalid, version.ID, version.ResumeID, app.ResumeID)
+		}
+	}
+	if app.ResumeID != "" {
+		if _, _, err := s.repo.GetResume(ctx, app.ResumeID); err != nil {
+			return linkError(err)
+		}
+	}
+	if app.JobID == "" {
+		return nil
+	}
+
+	job, err := s.repo.GetJob(ctx, app.JobID)
+	if err != nil {
+		return linkError(err)
+	}
+	if app.URL == "" {
+		app.URL = job.URL
+	}
+	if job.Skills != nil {
+		info := job.Skills.CompanyInfo
+		app.Company = orDefault(app.Company, info.Name)
+		app.Position = orDefault(app.Position, info.Position)
+		app.Level = orDefault(app.Level, info.Level)
+	}
+	return nil
+}
+
+// linkError turns a missing linked record into a validation error, the application
+// itself was found (or is being created) so a 404 would be misleading.
+func linkError(err error) error {
+	if errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("%w: links to an unknown record (%v)", ErrInvalid, err)
+	}
+	return err
+}
+
+func validate(app *types.Application) error {
+	app.Company = strings.TrimSpace(app.Company)
+	app.Position = strings.TrimSpace(app.Position)
+	app.CompanySize = strings.ToLower(strings.TrimSpace(app.CompanySize))
+	switch {
+	case app.Company == "":
+		return fmt.Errorf("%w: company is required", ErrInvalid)
+	case app.Position == "":
+		return fmt.Errorf("%w: position is required", ErrInvalid)
+	case app.Score != nil && (*app.Score < 0 || *app.Score > 10):
+		return fmt.Errorf("%w: score must be between 0 and 10", ErrInvalid)
+	case app.CompanySize != "" && !slices.Contains(companySizes, app.CompanySize):
+		return fmt.Errorf("%w: company_size must be one of %v", ErrInvalid, companySizes)
+	}
+	return nil
+}
+
+func orDefault(value, fallback string) string {
+	if strings.TrimSpace(value) != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,211 @@
+package applications
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/p-shah256/tracker/internal/storage"
+	"github.com/p-shah256/tracker/internal/storage/storagetest"
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+func newTestService(t *testing.T) *Service {
+	return NewService(storagetest.Open(t))
+}
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to types.ApplicationStatus
+		ok       bool
+	}{
+		{types.StatusSaved, types.StatusApplied, true},
+		{types.StatusApplied, types.StatusInterviewing, true},
+		{types.StatusOffer, types.StatusWithdrawn, true},
+		{types.StatusSaved, types.StatusRejected, true},
+		{types.StatusApplied, types.StatusApplied, false},
+		{types.StatusInterviewing, types.StatusScreening, false},
+		{types.StatusRejected, types.StatusApplied, false},
+		{types.StatusWithdrawn, types.StatusRejected, false},
+	}
+	for _, tt := range tests {
+		err := CanTransition(tt.from, tt.to)
+		if (err == nil) != tt.ok {
+			t.Errorf("CanTransition(%s, %s) = %v, want ok %v", tt.from, tt.to, err, tt.ok)
+		}
+		if err != nil && !errors.Is(err, ErrInvalidTransition) {
+			t.Errorf("CanTransition(%s, %s) error %v isn't ErrInvalidTransition", tt.from, tt.to, err)
+		}
+	}
+}
+
+func TestParseStatus(t *testing.T) {
+	if got, err := ParseStatus(" Interviewing "); err != nil || got != types.StatusInterviewing {
+		t.Errorf("ParseStatus = %q, %v", got, err)
+	}
+	if _, err := ParseStatus("ghosted"); !errors.Is(err, ErrInvalid) {
+		t.Errorf("ParseStatus(ghosted) error = %v, want ErrInvalid", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	s := newTestService(t)
+	score := func(f float64) *float64 { return &f }
+	tests := []struct {
+		name string
+		app  types.Application
+		ok   bool
+	}{
+		{"minimal", types.Application{Company: " Globex ", Position: "Engineer"}, true},
+		{"no company", types.Application{Position: "Engineer"}, false},
+		{"no position", types.Application{Company: "Globex"}, false},
+		{"score out of range", types.Application{Company: "Globex", Position: "Engineer", Score: score(11)}, false},
+		{"company size", types.Application{Company: "Globex", Position: "Engineer", CompanySize: "Startup"}, true},
+		{"unknown company size", types.Application{Company: "Globex", Position: "Engineer", CompanySize: "huge"}, false},
+		{"unknown status", types.Application{Company: "Globex", Position: "Engineer", Status: "ghosted"}, false},
+		{"unknown score run", types.Application{Company: "Globex", Position: "Engineer", ScoreRunID: "nope"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.Validate(context.Background(), &tt.app)
+			if (err == nil) != tt.ok {
+				t.Fatalf("Validate() = %v, want ok %v", err, tt.ok)
+			}
+			if err != nil && !errors.Is(err, ErrInvalid) {
+				t.Errorf("error %v isn't ErrInvalid", err)
+			}
+			if err == nil && tt.app.Status != types.StatusSaved {
+				t.Errorf("status = %q, want saved by default", tt.app.Status)
+			}
+		})
+	}
+}
+
+func TestSetStatus(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	app := &types.Application{Company: "Globex", Position: "Engineer", Status: types.StatusApplied}
+	if err := s.Create(ctx, app); err != nil {
+		t.Fatal(err)
+	}
+
+	at := time.Now().Add(time.Hour)
+	got, err := s.SetStatus(ctx, app.ID, types.StatusInterviewing, "onsite booked", at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != types.StatusInterviewing || len(got.History) != 2 || got.History[1].Note != "onsite booked" {
+		t.Errorf("application = %+v", got)
+	}
+
+	if _, err := s.SetStatus(ctx, app.ID, types.StatusScreening, "", time.Time{}); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("moving back error = %v, want ErrInvalidTransition", err)
+	}
+	if _, err := s.SetStatus(ctx, app.ID, types.StatusOffer, "", at.Add(-time.Minute)); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("backdating before the last change error = %v, want ErrInvalidTransition", err)
+	}
+	if _, err := s.SetStatus(ctx, "nope", types.StatusOffer, "", time.Time{}); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("unknown application error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestDuplicatesAndMerge(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	first := &types.Application{Company: "Acme, Inc.", Position: "Sr. Backend Engineer", Notes: "referral from Sam"}
+	if err := s.Create(ctx, first); err != nil {
+		t.Fatal(err)
+	}
+	if len(first.Duplicates) != 0 {
+		t.Errorf("first application has duplicates %+v", first.Duplicates)
+	}
+	if err := s.Create(ctx, &types.Application{Company: "Globex", Position: "Backend Engineer", URL: "https://jobs.example.com/1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	second := &types.Application{Company: "ACME", Position: "Senior Backend Engineer (Remote)", Level: "senior", Status: types.StatusApplied}
+	if err := s.Create(ctx, second); err != nil {
+		t.Fatal(err)
+	}
+	if len(second.Duplicates) != 1 || second.Duplicates[0].ApplicationID != first.ID {
+		t.Fatalf("duplicates = %+v, want the first application", second.Duplicates)
+	}
+
+	byURL := &types.Application{Company: "Someone Else", Position: "Recruiter Post", URL: "https://jobs.example.com/1"}
+	if err := s.Create(ctx, byURL); err != nil {
+		t.Fatal(err)
+	}
+	if len(byURL.Duplicates) != 1 || byURL.Duplicates[0].Company != "Globex" {
+		t.Errorf("duplicates = %+v, want the Globex application by URL", byURL.Duplicates)
+	}
+
+	if _, err := s.Merge(ctx, first.ID, first.ID); !errors.Is(err, ErrInvalid) {
+		t.Errorf("merging into itself error = %v, want ErrInvalid", err)
+	}
+	merged, err := s.Merge(ctx, first.ID, second.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged.Level != "senior" || merged.Status != types.StatusApplied || merged.Notes != "referral from Sam" {
+		t.Errorf("merged = %+v", merged)
+	}
+	if _, err := s.Get(ctx, second.ID); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("duplicate still exists, error = %v", err)
+	}
+
+	// a duplicate moved on more recently doesn't pull the application back a stage
+	ahead := &types.Application{Company: "Initech", Position: "Platform Engineer", Status: types.StatusInterviewing}
+	if err := s.Create(ctx, ahead); err != nil {
+		t.Fatal(err)
+	}
+	behind := &types.Application{Company: "Initech", Position: "Platform Engineer"}
+	if err := s.Create(ctx, behind); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.SetStatus(ctx, behind.ID, types.StatusApplied, "", time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	merged, err = s.Merge(ctx, ahead.ID, behind.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged.Status != types.StatusInterviewing {
+		t.Errorf("merged status = %s, want interviewing", merged.Status)
+	}
+
+	// a rejection that came last closes the merged application
+	if _, err := s.SetStatus(ctx, byURL.ID, types.StatusRejected, "", time.Now().Add(2*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	merged, err = s.Merge(ctx, ahead.ID, byURL.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged.Status != types.StatusRejected {
+		t.Errorf("merged status = %s, want rejected", merged.Status)
+	}
+}
+
+func TestDuplicatesStayWithTheirUser(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	mine := &types.Application{UserID: "ada", Company: "Acme", Position: "Backend Engineer"}
+	theirs := &types.Application{UserID: "grace", Company: "Acme", Position: "Backend Engineer"}
+	nobodys := &types.Application{Company: "Acme", Position: "Backend Engineer"}
+	for _, app := range []*types.Application{mine, theirs, nobodys} {
+		if err := s.Create(ctx, app); err != nil {
+			t.Fatal(err)
+		}
+		if len(app.Duplicates) != 0 {
+			t.Errorf("%q's application matched %+v", app.UserID, app.Duplicates)
+		}
+	}
+
+	if _, err := s.Merge(ctx, mine.ID, theirs.ID); !errors.Is(err, ErrInvalid) {
+		t.Errorf("merging another user's application error = %v, want ErrInvalid", err)
+	}
+	if _, err := s.Get(ctx, theirs.ID); err != nil {
+		t.Errorf("the other user's application is gone: %v", err)
+	}
+}
@@ -0,0 +1,190 @@
+package cleaner
+
+import (
+	"regexp"
+	"strings"
+)
+
+type Label string
+
+const (
+	LabelResponsibilities Label = "responsibilities"
+	LabelRequirements     Label = "requirements"
+	LabelNiceToHave       Label = "nice_to_have"
+	LabelBenefits         Label = "benefits"
+	LabelCompany          Label = "company"
+	LabelLegal            Label = "legal"
+	LabelOther            Label = "other"
+)
+
+// Noise is text that never describes the work itself: benefits, EEO and privacy boilerplate.
+func (l Label) Noise() bool {
+	return l == LabelBenefits || l == LabelLegal
+}
+
+type Block struct {
+	Label   Label  `json:"label"`
+	Heading bool   `json:"heading,omitempty"`
+	Text    string `json:"text"`
+}
+
+type labelRule struct {
+	label   Label
+	pattern *regexp.Regexp
+}
+
+func rule(label Label, pattern string) labelRule {
+	return labelRule{label: label, pattern: regexp.MustCompile(`(?i)` + pattern)}
+}
+
+// headingRules decide what the paragraphs under a heading are about. Order matters,
+// "preferred qualifications" has to hit nice_to_have before requirements sees "qualifications".
+var headingRules = []labelRule{
+	rule(LabelNiceToHave, `nice[ -]to[ -]haves?|bonus|preferred|pluses|extra credit|desired|stand out`),
+	rule(LabelRequirements, `requirement|qualification|what you.?ll (need|bring)|who you are|must[ -]haves?|what we.?re looking for|about you|^(your |required )?(skills|experience)( (and|&) \w+)?:?$`),
+	rule(LabelResponsibilities, `responsibilit|what you.?ll (do|work on)|the role|your impact|day[ -]to[ -]day|in this role|duties|the job|the opportunity`),
+	rule(LabelBenefits, `benefit|perks|what we offer|compensation|salary|pay range|why (join|work)|we offer|total rewards`),
+	// legal phrases are anchored, "Privacy policy compliance" or "Pay transparency tooling"
+	// under requirements is a requirement and must not end the section
+	rule(LabelLegal, `equal (employment )?opportunit|\beeoc?\b|e-verify|commitment to diversity|diversity,? (equity,? )?(and|&) inclusion|disclaimer:?$|^((applicant|candidate|data) )?privacy (notice|policy|statement)( for [\w ,]+)?\s*:?$|^(reasonable )?accommodations?( (notice|statement|policy|requests?))?\s*:?$|^legal (notice|disclaimer|statement)|^notice (to|for) |^pay transparency( (notice|statement|act))?\s*:?$|^(legal|privacy|diversity)\s*:?$`),
+	rule(LabelCompany, `about (us|the company|the team)|who we are|our (mission|story|team|company)|company overview|life at`),
+}
+
+// paragraphRules override the section, boilerplate often sits under an unrelated heading.
+// They only apply where boilerplate is expected (see overridable), a requirement about
+// privacy law or pay transparency tooling is still a requirement.
+var paragraphRules = []labelRule{
+	rule(LabelLegal, `equal (employment )?opportunity|without regard to|race,? colou?r|sexual orientation|gender identity|protected veteran|reasonable accommodation|e-verify|privacy (notice|policy)|personal (data|information)|applicant privacy|fair chance|arrest (and|or) conviction|pay transparency|affirmative action|recruitment agenc`),
+	rule(LabelBenefits, `401\(?k\)?|health(care)?,? dental|dental,? (and )?vision|medical,? dental|paid time off|\bpto\b|parental leave|unlimited vacation|stock options|equity (package|grant)|wellness (stipend|budget)|commuter benefit|learning (stipend|budget)|salary range|base (salary|pay) range|annual base|compensation range`),
+}
+
+// contentRules classify paragraphs that sit under no recognisable heading.
+var contentRules = []labelRule{
+	rule(LabelNiceToHave, `nice to have|is a plus|a bonus|preferred|ideally`),
+	rule(LabelRequirements, `\d\+? years|years of experience|experience (with|in)|proficien|familiarity with|degree in|strong (knowledge|understanding)|must have|required`),
+	rule(LabelResponsibilities, `^(you will|you.?ll|design|build|develop|own|lead|collaborate|work with|partner|drive|implement|maintain|mentor)\b`),
+	rule(LabelCompany, `^(we are|we.?re|founded in|our mission|at [A-Z])|is a leading|backed by`),
+}
+
+// Classify splits cleaned text on blank lines and labels each block with rule-based heuristics.
+// A Markdown list is split further into its items, so one item matching a paragraph rule
+// doesn't take the whole list with it.
+func Classify(text string) []Block {
+	var blocks []Block
+	section := LabelOther
+
+	for _, para := range paragraphs(text) {
+
+		if heading, ok := headingLabel(para); ok {
+			section = heading
+			blocks = append(blocks, Block{Label: section, Heading: true, Text: para})
+			continue
+		}
+
+		label := section
+		if overridable(section) {
+			label = match(paragraphRules, para, section)
+		}
+		if label == LabelOther {
+			label = match(contentRules, para, LabelOther)
+		}
+		blocks = append(blocks, Block{Label: label, Text: para})
+	}
+	return blocks
+}
+
+// overridable sections are the ones boilerplate ends up in. The sections describing the
+// work keep their label, whatever their paragraphs mention.
+func overridable(section Label) bool {
+	switch section {
+	case LabelOther, LabelCompany, LabelBenefits:
+		return true
+	}
+	return false
+}
+
+var listItem = regexp.MustCompile(`^([-*+]|\d+\.)\s`)
+
+// paragraphs splits text on blank lines, and Markdown lists into top level items with
+// their nested items.
+func paragraphs(text string) []string {
+	var paras []string
+	for _, raw := range strings.Split(text, "\n\n") {
+		para := strings.TrimSpace(raw)
+		if para == "" {
+			continue
+		}
+		if !listItem.MatchString(para) {
+			paras = append(paras, para)
+			continue
+		}
+		var item []string
+		for _, line := range strings.Split(para, "\n") {
+			if listItem.MatchString(line) && len(item) > 0 {
+				paras = append(paras, strings.Join(item, "\n"))
+				item = nil
+			}
+			item = append(item, line)
+		}
+		paras = append(paras, strings.Join(item, "\n"))
+	}
+	return paras
+}
+
+func match(rules []labelRule, text string, fallback Label) Label {
+	for _, r := range rules {
+		if r.pattern.MatchString(text) {
+			return r.label
+		}
+	}
+	return fallback
+}
+
+// headingLabel is a guess for plain text, where the HTML structure is gone. A short
+// line only counts as a heading if it names a known section, otherwise every one-word
+// list item ("Python") would end the section it belongs to.
+func headingLabel(para string) (Label, bool) {
+	if strings.Contains(para, "\n") || len(para) > 80 {
+		return "", false
+	}
+	// Markdown output says outright what is a heading
+	if strings.HasPrefix(para, "#") {
+		return match(headingRules, stripMarkdown(para), LabelOther), true
+	}
+	if strings.HasPrefix(para, "- ") {
+		return "", false
+	}
+	para = stripMarkdown(para)
+	label := match(headingRules, para, "")
+	if strings.HasSuffix(para, ":") {
+		if label == "" {
+			label = LabelOther
+		}
+		return label, true
+	}
+	if label == "" || len(strings.Fields(para)) > 6 || strings.ContainsAny(para[len(para)-1:], ".,;!") {
+		return "", false
+	}
+	return label, true
+}
+
+// Labeled renders the blocks that matter for skill extraction, grouped under their label.
+// Benefits and legal text is left out. Headings stay in, a false positive is usually a list item.
+func Labeled(blocks []Block) string {
+	var out strings.Builder
+	var current Label
+	for _, b := range blocks {
+		if b.Label.Noise() {
+			continue
+		}
+		if b.Label != current {
+			if out.Len() > 0 {
+				out.WriteString("\n")
+			}
+			out.WriteString("[" + strings.ToUpper(string(b.Label)) + "]\n")
+			current = b.Label
+		}
+		out.WriteString(b.Text + "\n")
+	}
+	return strings.TrimSpace(out.String())
+}
@@ -0,0 +1,120 @@
+package cleaner
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []Label
+	}{
+		{
+			name: "sections by heading",
+			text: "About us\n\nWe build rockets.\n\nResponsibilities\n\nShip the telemetry service.\n\nRequirements\n\n5+ years of Go\n\nNice to have\n\nRust\n\nBenefits\n\nGenerous PTO",
+			want: []Label{
+				LabelCompany, LabelCompany,
+				LabelResponsibilities, LabelResponsibilities,
+				LabelRequirements, LabelRequirements,
+				LabelNiceToHave, LabelNiceToHave,
+				LabelBenefits, LabelBenefits,
+			},
+		},
+		{
+			name: "boilerplate under an unrelated heading",
+			text: "About the company\n\nWe are an equal opportunity employer and value diversity.\n\nWe offer a 401(k) match and dental, vision coverage.",
+			want: []Label{LabelCompany, LabelLegal, LabelBenefits},
+		},
+		{
+			name: "boilerplate with no heading",
+			text: "Build the billing service.\n\nAll qualified applicants will receive consideration without regard to race, color or religion.",
+			want: []Label{LabelResponsibilities, LabelLegal},
+		},
+		{
+			name: "legal notice under benefits",
+			text: "Benefits:\n\nHealth, dental and vision.\n\nSee our applicant privacy notice for how we use personal data.",
+			want: []Label{LabelBenefits, LabelBenefits, LabelLegal},
+		},
+		{
+			name: "requirements that mention privacy and pay",
+			text: "Requirements\n\nExperience handling personal data / privacy policy compliance\n\nBuilt pay transparency tooling for HR teams\n\nAutomated affirmative action reporting\n\nKnowledge of 401(k) plan administration\n\nPay transparency tooling\n\nSQL",
+			want: []Label{LabelRequirements, LabelRequirements, LabelRequirements, LabelRequirements, LabelRequirements, LabelRequirements, LabelRequirements},
+		},
+		{
+			name: "nice to haves and responsibilities keep their label",
+			text: "Preferred qualifications\n\nFamiliarity with GDPR and personal information handling\n\nWhat you'll do\n\nOwn the equal opportunity reporting pipeline for our customers",
+			want: []Label{LabelNiceToHave, LabelNiceToHave, LabelResponsibilities, LabelResponsibilities},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocks := Classify(tt.text)
+			var got []Label
+			for _, b := range blocks {
+				got = append(got, b.Label)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d blocks %v, want %v", len(got), got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("block %d %q: label %q, want %q", i, blocks[i].Text, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestHeadingLabel(t *testing.T) {
+	tests := []struct {
+		text    string
+		label   Label
+		heading bool
+	}{
+		{"Requirements", LabelRequirements, true},
+		{"Preferred Qualifications:", LabelNiceToHave, true},
+		{"## What you'll do", LabelResponsibilities, true},
+		{"**Benefits**", LabelBenefits, true},
+		{"Equal Employment Opportunity", LabelLegal, true},
+		{"EEO Statement", LabelLegal, true},
+		{"Diversity, Equity & Inclusion", LabelLegal, true},
+		{"Privacy Notice", LabelLegal, true},
+		{"Legal:", LabelLegal, true},
+		{"Our commitment to diversity", LabelLegal, true},
+		{"## Notice period", LabelOther, true},
+		{"Notice period", "", false},
+		{"Legal tech experience", "", false},
+		{"Diversity of thought", "", false},
+		{"Diversity & Inclusion", LabelLegal, true},
+		{"Experience with privacy policy compliance", "", false},
+		{"Pay transparency tooling", "", false},
+		{"Privacy policy compliance", "", false},
+		{"Pay Transparency Notice", LabelLegal, true},
+		{"Applicant Privacy Notice for California Residents", LabelLegal, true},
+		{"Reasonable Accommodations", LabelLegal, true},
+		{"Python", "", false},
+		{"- Requirements", "", false},
+		{"Other details:", LabelOther, true},
+		{"You will design and build the core scheduling engine.", "", false},
+	}
+	for _, tt := range tests {
+		label, ok := headingLabel(tt.text)
+		if ok != tt.heading || label != tt.label {
+			t.Errorf("headingLabel(%q) = %q, %v, want %q, %v", tt.text, label, ok, tt.label, tt.heading)
+		}
+	}
+}
+
+func TestLabeled(t *testing.T) {
+	blocks := Classify("Requirements\n\nGo\n\nExperience with privacy policy compliance\n\nBenefits\n\nUnlimited vacation\n\nWe are an equal opportunity employer.")
+	got := Labeled(blocks)
+	want := "[REQUIREMENTS]\nRequirements\nGo\nExperience with privacy policy compliance"
+	if got != want {
+		t.Errorf("Labeled() =\n%s\nwant\n%s", got, want)
+	}
+	if strings.Contains(got, "vacation") {
+		t.Error("benefits weren't dropped")
+	}
+}
@@ -1,26 +1,146 @@
 package cleaner
 
 import (
+	"net/url"
 	"regexp"
 	"strings"
 
 	"github.com/PuerkitoBio/goquery"
+
+	"github.com/p-shah256/tracker/pkg/types"
 )
 
-type Cleaner struct{}
+// Mode is what CleanPage produces for HTML input.
+type Mode int
+
+const (
+	// ModeText is flat paragraphs separated by blank lines
+	ModeText Mode = iota
+	// ModeMarkdown keeps headings, nested lists, bold labels and tables
+	ModeMarkdown
+)
+
+type Cleaner struct {
+	mode Mode
+}
 
 func NewCleaner() *Cleaner {
 	return &Cleaner{}
 }
 
+// WithMode returns a cleaner that emits the given output mode.
+func (c *Cleaner) WithMode(mode Mode) *Cleaner {
+	return &Cleaner{mode: mode}
+}
+
+func (c *Cleaner) toText(html string) string {
+	if c.mode == ModeMarkdown {
+		return c.CleanMarkdown(html)
+	}
+	return c.CleanHTML(html)
+}
+
+// Result is a cleaned job description along with whatever structured metadata the page carried.
+type Result struct {
+	Text    string
+	Posting *types.JobPosting
+	// Site is the job board whose layout was recognised, empty for generic pages
+	Site string
+	// Blocks is Text split into paragraphs, each labeled by Classify
+	Blocks []Block
+}
+
+func (c *Cleaner) Clean(page string) *Result {
+	return c.CleanPage(page, "")
+}
+
+// CleanPage picks the posting body in order of trust: a known job board layout, then the
+// description from the page's schema.org JobPosting, then the whole page. Text is in the
+// cleaner's Mode.
+// pageURL is optional and only helps recognise the board.
+func (c *Cleaner) CleanPage(page, pageURL string) *Result {
+	result := c.cleanPage(page, pageURL)
+	result.Blocks = Classify(result.Text)
+	return result
+}
+
+func (c *Cleaner) cleanPage(page, pageURL string) *Result {
+	// pasted plain text would lose every line break in CleanHTML
+	if !htmlTag.MatchString(page) {
+		return &Result{Text: cleanLines(page)}
+	}
+
+	posting := c.ExtractJobPosting(page)
+
+	if site, body, sitePosting := c.extractSite(page, pageURL); site != "" {
+		posting = mergePostings(posting, sitePosting)
+		if text := c.toText(body); text != "" {
+			return &Result{Text: text, Posting: posting, Site: site}
+		}
+	}
+
+	if posting != nil && posting.Description != "" {
+		return &Result{Text: posting.Description, Posting: posting}
+	}
+	return &Result{Text: c.toText(page), Posting: posting}
+}
+
+func (c *Cleaner) extractSite(page, pageURL string) (string, string, *types.JobPosting) {
+	if !strings.Contains(page, "<") {
+		return "", "", nil
+	}
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(page))
+	if err != nil {
+		return "", "", nil
+	}
+
+	var u *url.URL
+	if pageURL != "" {
+		u, _ = url.Parse(pageURL)
+	}
+	site := matchSite(u, doc)
+	if site == nil {
+		return "", "", nil
+	}
+
+	body, posting := site.Extract(doc)
+	if strings.TrimSpace(body) == "" {
+		return "", "", nil
+	}
+	return site.Name(), body, posting
+}
+
+// mergePostings keeps JSON-LD values and fills the gaps from the page layout.
+func mergePostings(ld, site *types.JobPosting) *types.JobPosting {
+	if site == nil {
+		return ld
+	}
+	if ld == nil {
+		if *site == (types.JobPosting{}) {
+			return nil
+		}
+		return site
+	}
+	merged := *ld
+	fill := func(dst *string, src string) {
+		if *dst == "" {
+			*dst = src
+		}
+	}
+	fill(&merged.Title, site.Title)
+	fill(&merged.HiringOrganization, site.HiringOrganization)
+	fill(&merged.Location, site.Location)
+	fill(&merged.EmploymentType, site.EmploymentType)
+	fill(&merged.DatePosted, site.DatePosted)
+	return &merged
+}
+
 func (c *Cleaner) CleanHTML(html string) string {
 	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
 	if err != nil {
 		return stripTags(html)
 	}
-	doc.Find("script, style, nav, header, footer, iframe, noscript").Remove()
-	doc.Find(".menu, .navigation, .social, .banner, .ads, .cookie, .popup").Remove()
-	doc.Find("div:empty, span:empty").Remove()
+	removeNoise(doc)
 	var textBlocks []string
 	doc.Find("p, li, h1, h2, h3, h4, h5, h6").Each(func(i int, s *goquery.Selection) {
 		text := strings.TrimSpace(s.Text())
@@ -40,6 +160,12 @@ func (c *Cleaner) CleanHTML(html string) string {
 	return cleanText(doc.Text())
 }
 
+func removeNoise(doc *goquery.Document) {
+	doc.Find("script, style, nav, header, footer, iframe, noscript").Remove()
+	doc.Find(".menu, .navigation, .social, .banner, .ads, .cookie, .popup").Remove()
+	doc.Find("div:empty, span:empty").Remove()
+}
+
 func (c *Cleaner) CleanLlmResponse(response string) string {
 	if !strings.Contains(response, "```") {
 		return strings.TrimSpace(response)
@@ -64,6 +190,19 @@ func (c *Cleaner) CleanLlmResponse(response string) string {
 	return strings.TrimSpace(response)
 }
 
+var htmlTag = regexp.MustCompile(`<(?i:[a-z][a-z0-9]*)[^>]*>`)
+
+// cleanLines keeps one paragraph per non-empty line.
+func cleanLines(text string) string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = cleanText(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n\n")
+}
+
 func stripTags(html string) string {
 	re := regexp.MustCompile("<[^>]*>")
 	text := re.ReplaceAllString(html, " ")
@@ -0,0 +1,206 @@
+package cleaner
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+// ExtractJobPosting returns the first JobPosting found in the page's JSON-LD scripts, or nil.
+func (c *Cleaner) ExtractJobPosting(page string) *types.JobPosting {
+	if !strings.Contains(page, "ld+json") {
+		return nil
+	}
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(page))
+	if err != nil {
+		return nil
+	}
+
+	var posting *types.JobPosting
+	doc.Find(`script[type="application/ld+json"]`).EachWithBreak(func(i int, s *goquery.Selection) bool {
+		var data any
+		if err := json.Unmarshal([]byte(s.Text()), &data); err != nil {
+			return true
+		}
+		if node := findJobPosting(data); node != nil {
+			posting = c.toJobPosting(node)
+			return false
+		}
+		return true
+	})
+	return posting
+}
+
+// findJobPosting walks top level arrays and @graph containers looking for a JobPosting node.
+func findJobPosting(data any) map[string]any {
+	switch v := data.(type) {
+	case []any:
+		for _, item := range v {
+			if node := findJobPosting(item); node != nil {
+				return node
+			}
+		}
+	case map[string]any:
+		if hasType(v, "JobPosting") {
+			return v
+		}
+		if graph, ok := v["@graph"]; ok {
+			return findJobPosting(graph)
+		}
+	}
+	return nil
+}
+
+func hasType(node map[string]any, want string) bool {
+	for _, t := range asList(node["@type"]) {
+		if s, ok := t.(string); ok && (s == want || strings.HasSuffix(s, "/"+want)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Cleaner) toJobPosting(node map[string]any) *types.JobPosting {
+	posting := &types.JobPosting{
+		Title:              str(node["title"]),
+		HiringOrganization: name(node["hiringOrganization"]),
+		Location:           location(node),
+		EmploymentType:     joinStrings(node["employmentType"]),
+		Salary:             salary(node["baseSalary"]),
+		DatePosted:         str(node["datePosted"]),
+	}
+	// description is HTML, some boards entity-escape it once more
+	if desc := str(node["description"]); desc != "" {
+		if !strings.Contains(desc, "<") && strings.Contains(desc, "&lt;") {
+			desc = html.UnescapeString(desc)
+		}
+		posting.Description = c.toText(desc)
+	}
+	return posting
+}
+
+func location(node map[string]any) string {
+	var places []string
+	for _, loc := range asList(node["jobLocation"]) {
+		if place := address(loc); place != "" {
+			places = append(places, place)
+		}
+	}
+	if strings.EqualFold(str(node["jobLocationType"]), "TELECOMMUTE") {
+		places = append(places, "Remote")
+	}
+	return strings.Join(places, "; ")
+}
+
+func address(loc any) string {
+	place, ok := loc.(map[string]any)
+	if !ok {
+		return str(loc)
+	}
+	addr, ok := place["address"].(map[string]any)
+	if !ok {
+		if s := str(place["address"]); s != "" {
+			return s
+		}
+		return name(place)
+	}
+
+	var parts []string
+	for _, key := range []string{"addressLocality", "addressRegion", "addressCountry"} {
+		if part := name(addr[key]); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func salary(v any) string {
+	amount, ok := v.(map[string]any)
+	if !ok {
+		return str(v)
+	}
+	currency := str(amount["currency"])
+
+	var value, unit string
+	switch q := amount["value"].(type) {
+	case map[string]any:
+		unit = str(q["unitText"])
+		minValue, maxValue := number(q["minValue"]), number(q["maxValue"])
+		switch {
+		case minValue != "" && maxValue != "" && minValue != maxValue:
+			value = minValue + "-" + maxValue
+		case minValue != "":
+			value = minValue
+		case maxValue != "":
+			value = maxValue
+		default:
+			value = number(q["value"])
+		}
+	default:
+		value = number(q)
+	}
+	if value == "" {
+		return ""
+	}
+
+	out := strings.TrimSpace(currency + " " + value)
+	if unit != "" {
+		out += " per " + strings.ToLower(unit)
+	}
+	return out
+}
+
+func name(v any) string {
+	if node, ok := v.(map[string]any); ok {
+		return str(node["name"])
+	}
+	return str(v)
+}
+
+func joinStrings(v any) string {
+	var parts []string
+	for _, item := range asList(v) {
+		if s := str(item); s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func asList(v any) []any {
+	if list, ok := v.([]any); ok {
+		return list
+	}
+	if v == nil {
+		return nil
+	}
+	return []any{v}
+}
+
+func str(v any) string {
+	switch s := v.(type) {
+	case string:
+		return strings.TrimSpace(s)
+	case float64:
+		return number(s)
+	}
+	return ""
+}
+
+func number(v any) string {
+	switch n := v.(type) {
+	case float64:
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	case string:
+		return strings.TrimSpace(n)
+	case nil:
+		return ""
+	}
+	return fmt.Sprint(v)
+}
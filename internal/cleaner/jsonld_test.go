@@ -0,0 +1,96 @@
+package cleaner
+
+import (
+	"testing"
+
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+func TestExtractJobPosting(t *testing.T) {
+	tests := []struct {
+		name string
+		page string
+		want *types.JobPosting
+	}{
+		{
+			name: "no JSON-LD",
+			page: `<html><body><h1>Engineer</h1></body></html>`,
+			want: nil,
+		},
+		{
+			name: "plain JobPosting",
+			page: `<script type="application/ld+json">{
+				"@context": "https://schema.org", "@type": "JobPosting",
+				"title": " Backend Engineer ",
+				"hiringOrganization": {"@type": "Organization", "name": "Acme"},
+				"jobLocation": {"@type": "Place", "address": {"addressLocality": "Berlin", "addressCountry": "DE"}},
+				"employmentType": ["FULL_TIME", "CONTRACTOR"],
+				"baseSalary": {"currency": "EUR", "value": {"minValue": 70000, "maxValue": 90000, "unitText": "YEAR"}},
+				"datePosted": "2025-01-02",
+				"description": "<p>Build APIs.</p><ul><li>Go</li></ul>"
+			}</script>`,
+			want: &types.JobPosting{
+				Title:              "Backend Engineer",
+				HiringOrganization: "Acme",
+				Location:           "Berlin, DE",
+				EmploymentType:     "FULL_TIME, CONTRACTOR",
+				Salary:             "EUR 70000-90000 per year",
+				DatePosted:         "2025-01-02",
+				Description:        "Build APIs.\n\nGo",
+			},
+		},
+		{
+			name: "inside @graph, remote, escaped description",
+			page: `<script type="application/ld+json">{"@graph": [
+				{"@type": "WebPage", "name": "Careers"},
+				{"@type": ["Thing", "http://schema.org/JobPosting"], "title": "SRE",
+				 "hiringOrganization": "Initech", "jobLocationType": "TELECOMMUTE",
+				 "baseSalary": {"currency": "USD", "value": {"value": 50, "unitText": "HOUR"}},
+				 "description": "&lt;p&gt;Keep things up.&lt;/p&gt;"}
+			]}</script>`,
+			want: &types.JobPosting{
+				Title:              "SRE",
+				HiringOrganization: "Initech",
+				Location:           "Remote",
+				Salary:             "USD 50 per hour",
+				Description:        "Keep things up.",
+			},
+		},
+		{
+			name: "broken script is skipped",
+			page: `<script type="application/ld+json">{"@type": "JobPosting",</script>
+				<script type="application/ld+json">[{"@type": "Organization"}, {"@type": "JobPosting", "title": "QA"}]</script>`,
+			want: &types.JobPosting{Title: "QA"},
+		},
+		{
+			name: "no JobPosting node",
+			page: `<script type="application/ld+json">{"@type": "Organization", "name": "Acme"}</script>`,
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewCleaner().ExtractJobPosting(tt.page)
+			switch {
+			case got == nil && tt.want == nil:
+			case got == nil || tt.want == nil:
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			case *got != *tt.want:
+				t.Errorf("got  %+v\nwant %+v", *got, *tt.want)
+			}
+		})
+	}
+}
+
+func TestCleanPagePrefersJobPosting(t *testing.T) {
+	page := `<html><body><nav>Home</nav><p>Cookie banner text</p>
+		<script type="application/ld+json">{"@type": "JobPosting", "title": "Engineer", "description": "<p>The real description.</p>"}</script>
+		</body></html>`
+	result := NewCleaner().CleanPage(page, "")
+	if result.Text != "The real description." {
+		t.Errorf("Text = %q, want the JobPosting description", result.Text)
+	}
+	if result.Posting == nil || result.Posting.Title != "Engineer" {
+		t.Errorf("Posting = %+v", result.Posting)
+	}
+}
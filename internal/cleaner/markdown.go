@@ -0,0 +1,274 @@
+package cleaner
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// CleanMarkdown is CleanHTML that keeps the page structure: headings, nested lists,
+// bold labels and tables come out as Markdown instead of flat paragraphs.
+func (c *Cleaner) CleanMarkdown(page string) string {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(page))
+	if err != nil {
+		return stripTags(page)
+	}
+	removeNoise(doc)
+
+	root := doc.Find("body")
+	if root.Length() == 0 {
+		root = doc.Selection
+	}
+
+	md := &mdWriter{}
+	for _, n := range root.Nodes {
+		md.blocks(n)
+	}
+	md.flush()
+	return md.String()
+}
+
+type mdWriter struct {
+	out    []string
+	inline strings.Builder
+}
+
+func (w *mdWriter) String() string {
+	return strings.Join(w.out, "\n\n")
+}
+
+func (w *mdWriter) emit(block string) {
+	if block = strings.TrimRight(block, " \n"); strings.TrimSpace(block) != "" {
+		w.out = append(w.out, block)
+	}
+}
+
+// flush turns loose inline content collected so far into a paragraph. A short line
+// ending in a colon is a label ("Requirements:") and is bolded so it reads as one.
+func (w *mdWriter) flush() {
+	text := cleanText(w.inline.String())
+	w.inline.Reset()
+	if text == "" {
+		return
+	}
+	if strings.HasSuffix(text, ":") && len(text) <= 60 && !strings.HasPrefix(text, "**") {
+		text = "**" + text + "**"
+	}
+	w.emit(text)
+}
+
+func (w *mdWriter) blocks(n *html.Node) {
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.TextNode {
+			w.inline.WriteString(child.Data)
+			continue
+		}
+		if child.Type != html.ElementNode {
+			continue
+		}
+
+		switch child.Data {
+		case "h1", "h2", "h3", "h4", "h5", "h6":
+			w.flush()
+			if text := cleanText(inlineText(child)); text != "" {
+				level := int(child.Data[1] - '0')
+				w.emit(strings.Repeat("#", level) + " " + strings.ReplaceAll(text, "**", ""))
+			}
+		case "p":
+			w.flush()
+			w.inline.WriteString(inlineText(child))
+			w.flush()
+		case "ul", "ol":
+			w.flush()
+			var list strings.Builder
+			writeList(&list, child, 0)
+			w.emit(list.String())
+		case "table":
+			w.flush()
+			w.emit(table(child))
+		case "pre":
+			w.flush()
+			w.emit("```\n" + strings.Trim(textContent(child), "\n") + "\n```")
+		case "br":
+			w.flush()
+		case "hr":
+			w.flush()
+			w.emit("---")
+		case "div", "section", "article", "main", "aside", "blockquote", "dl", "dd", "dt", "form", "fieldset", "span", "body", "html", "center":
+			if isBlockContainer(child) {
+				w.flush()
+				w.blocks(child)
+				w.flush()
+			} else {
+				w.inline.WriteString(inlineText(child))
+			}
+		default:
+			w.inline.WriteString(inlineText(child))
+		}
+	}
+}
+
+var blockTags = map[string]bool{
+	"p": true, "div": true, "ul": true, "ol": true, "table": true, "pre": true, "section": true, "article": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true, "br": true, "hr": true, "blockquote": true,
+	"main": true, "aside": true, "dl": true, "dd": true, "dt": true, "form": true, "fieldset": true, "center": true,
+}
+
+func isBlockContainer(n *html.Node) bool {
+	if n.Data != "span" {
+		return true
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode && blockTags[child.Data] {
+			return true
+		}
+	}
+	return false
+}
+
+func writeList(b *strings.Builder, list *html.Node, depth int) {
+	ordered := list.Data == "ol"
+	index := 0
+	for li := list.FirstChild; li != nil; li = li.NextSibling {
+		if li.Type != html.ElementNode || li.Data != "li" {
+			continue
+		}
+		index++
+
+		var text strings.Builder
+		var nested []*html.Node
+		for child := li.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type == html.ElementNode && (child.Data == "ul" || child.Data == "ol") {
+				nested = append(nested, child)
+				continue
+			}
+			if child.Type == html.TextNode {
+				text.WriteString(child.Data)
+			} else {
+				text.WriteString(" " + inlineText(child) + " ")
+			}
+		}
+
+		marker := "-"
+		if ordered {
+			marker = strconv.Itoa(index) + "."
+		}
+		if item := cleanText(text.String()); item != "" || len(nested) > 0 {
+			b.WriteString(strings.Repeat("  ", depth) + marker + " " + item + "\n")
+		}
+		for _, n := range nested {
+			writeList(b, n, depth+1)
+		}
+	}
+}
+
+func table(t *html.Node) string {
+	var rows [][]string
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type != html.ElementNode {
+				continue
+			}
+			if child.Data != "tr" {
+				walk(child)
+				continue
+			}
+			var row []string
+			for cell := child.FirstChild; cell != nil; cell = cell.NextSibling {
+				if cell.Type == html.ElementNode && (cell.Data == "td" || cell.Data == "th") {
+					row = append(row, strings.ReplaceAll(cleanText(inlineText(cell)), "|", `\|`))
+				}
+			}
+			if len(row) > 0 {
+				rows = append(rows, row)
+			}
+		}
+	}
+	walk(t)
+	if len(rows) == 0 {
+		return ""
+	}
+
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row))
+	}
+	var b strings.Builder
+	for i, row := range rows {
+		for len(row) < width {
+			row = append(row, "")
+		}
+		b.WriteString("| " + strings.Join(row, " | ") + " |\n")
+		if i == 0 {
+			b.WriteString("|" + strings.Repeat(" --- |", width) + "\n")
+		}
+	}
+	return b.String()
+}
+
+// inlineText renders a node's content on one line, keeping bold and italics.
+func inlineText(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	if n.Type != html.ElementNode {
+		return ""
+	}
+
+	var b strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		b.WriteString(inlineText(child))
+	}
+	inner := b.String()
+
+	switch n.Data {
+	case "strong", "b":
+		return wrap(inner, "**")
+	case "em", "i":
+		return wrap(inner, "*")
+	case "code":
+		return wrap(inner, "`")
+	case "br", "p", "div", "li", "td", "th":
+		return " " + inner + " "
+	}
+	return inner
+}
+
+// wrap keeps the surrounding spaces outside the markers, "** Go **" is not bold in Markdown.
+func wrap(s, marker string) string {
+	trimmed := cleanText(s)
+	if trimmed == "" {
+		return s
+	}
+	lead, trail := "", ""
+	if strings.HasPrefix(s, " ") || strings.HasPrefix(s, "\n") {
+		lead = " "
+	}
+	if strings.HasSuffix(s, " ") || strings.HasSuffix(s, "\n") {
+		trail = " "
+	}
+	return lead + marker + trimmed + marker + trail
+}
+
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var b strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		b.WriteString(textContent(child))
+	}
+	return b.String()
+}
+
+var markdownPrefix = regexp.MustCompile(`^(#{1,6}\s+|[-*+]\s+|\d+\.\s+)`)
+
+// stripMarkdown drops heading and list markers and emphasis, for the classifier's benefit.
+func stripMarkdown(line string) string {
+	line = markdownPrefix.ReplaceAllString(strings.TrimSpace(line), "")
+	return strings.TrimSpace(strings.NewReplacer("**", "", "`", "").Replace(line))
+}
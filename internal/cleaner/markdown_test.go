@@ -0,0 +1,110 @@
+package cleaner
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCleanMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "headings and paragraphs",
+			html: `<h2>About the role</h2><p>We build <strong>payments</strong> infrastructure.</p>`,
+			want: "## About the role\n\nWe build **payments** infrastructure.",
+		},
+		{
+			name: "nested and ordered lists",
+			html: `<ul><li>Go<ul><li>generics</li></ul></li><li>SQL</li></ul><ol><li>Apply</li><li>Interview</li></ol>`,
+			want: "- Go\n  - generics\n- SQL\n\n1. Apply\n2. Interview",
+		},
+		{
+			name: "label line is bolded",
+			html: `<div>Requirements:<br>5 years of Go</div>`,
+			want: "**Requirements:**\n\n5 years of Go",
+		},
+		{
+			name: "table",
+			html: `<table><tr><th>Level</th><th>Range</th></tr><tr><td>Senior</td><td>$150k | $180k</td></tr></table>`,
+			want: "| Level | Range |\n| --- | --- |\n| Senior | $150k \\| $180k |",
+		},
+		{
+			name: "noise removed",
+			html: `<nav>Menu</nav><script>var x;</script><p>Real content</p><footer>Footer</footer>`,
+			want: "Real content",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewCleaner().CleanMarkdown(tt.html); got != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassifyMarkdownLists(t *testing.T) {
+	page := `<h2>Requirements</h2>
+		<ul>
+			<li>5+ years of Go</li>
+			<li>Experience handling personal data<ul><li>GDPR</li></ul></li>
+			<li>Kubernetes</li>
+		</ul>
+		<h2>About us</h2>
+		<ul>
+			<li>We ship weekly</li>
+			<li>We are an equal opportunity employer</li>
+			<li>Generous 401(k) match</li>
+		</ul>`
+	result := NewCleaner().WithMode(ModeMarkdown).CleanPage(page, "")
+
+	want := []struct {
+		label Label
+		text  string
+	}{
+		{LabelRequirements, "## Requirements"},
+		{LabelRequirements, "- 5+ years of Go"},
+		{LabelRequirements, "- Experience handling personal data\n  - GDPR"},
+		{LabelRequirements, "- Kubernetes"},
+		{LabelCompany, "## About us"},
+		{LabelCompany, "- We ship weekly"},
+		{LabelLegal, "- We are an equal opportunity employer"},
+		{LabelBenefits, "- Generous 401(k) match"},
+	}
+	if len(result.Blocks) != len(want) {
+		t.Fatalf("got %d blocks, want %d: %+v", len(result.Blocks), len(want), result.Blocks)
+	}
+	for i, w := range want {
+		b := result.Blocks[i]
+		if b.Label != w.label || b.Text != w.text {
+			t.Errorf("block %d = %q %q, want %q %q", i, b.Label, b.Text, w.label, w.text)
+		}
+	}
+
+	labeled := Labeled(result.Blocks)
+	for _, item := range []string{"5+ years of Go", "Kubernetes", "We ship weekly"} {
+		if !strings.Contains(labeled, item) {
+			t.Errorf("Labeled dropped %q:\n%s", item, labeled)
+		}
+	}
+	if strings.Contains(labeled, "401(k)") || strings.Contains(labeled, "equal opportunity") {
+		t.Errorf("Labeled kept boilerplate:\n%s", labeled)
+	}
+}
+
+func TestStripMarkdown(t *testing.T) {
+	tests := map[string]string{
+		"## **Requirements**": "Requirements",
+		"- `Go` experience":   "Go experience",
+		"12. Step":            "Step",
+		"plain":               "plain",
+	}
+	for in, want := range tests {
+		if got := stripMarkdown(in); got != want {
+			t.Errorf("stripMarkdown(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
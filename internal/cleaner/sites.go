@@ -0,0 +1,184 @@
+package cleaner
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+// SiteExtractor knows where a particular job board keeps the posting on its pages.
+type SiteExtractor interface {
+	Name() string
+	// Match is called with a nil URL when the page was pasted rather than fetched,
+	// so extractors should also recognise their own markup.
+	Match(pageURL *url.URL, doc *goquery.Document) bool
+	// Extract returns the HTML of the posting body and any metadata shown in the layout.
+	Extract(doc *goquery.Document) (string, *types.JobPosting)
+}
+
+var (
+	sitesMu sync.RWMutex
+	sites   = []SiteExtractor{greenhouse, lever, ashby, workday, smartRecruiters}
+)
+
+// RegisterSite adds an extractor, it is tried before the built-in ones.
+func RegisterSite(e SiteExtractor) {
+	sitesMu.Lock()
+	defer sitesMu.Unlock()
+	sites = append([]SiteExtractor{e}, sites...)
+}
+
+func matchSite(pageURL *url.URL, doc *goquery.Document) SiteExtractor {
+	sitesMu.RLock()
+	defer sitesMu.RUnlock()
+	for _, site := range sites {
+		if site.Match(pageURL, doc) {
+			return site
+		}
+	}
+	return nil
+}
+
+// siteLayout describes a board by selectors. For every field the first selector with text wins.
+type siteLayout struct {
+	name    string
+	hosts   []string
+	markers []string
+	// remove runs after the metadata is read: application forms, "other openings", sidebars
+	remove         []string
+	body           []string
+	title          []string
+	company        []string
+	location       []string
+	employmentType []string
+	datePosted     []string
+}
+
+func (l *siteLayout) Name() string {
+	return l.name
+}
+
+func (l *siteLayout) Match(pageURL *url.URL, doc *goquery.Document) bool {
+	if pageURL != nil {
+		host := strings.ToLower(pageURL.Hostname())
+		for _, h := range l.hosts {
+			if host == h || strings.HasSuffix(host, "."+h) {
+				return true
+			}
+		}
+	}
+	for _, marker := range l.markers {
+		if doc.Find(marker).Length() > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *siteLayout) Extract(doc *goquery.Document) (string, *types.JobPosting) {
+	posting := &types.JobPosting{
+		Title:              firstText(doc, l.title),
+		HiringOrganization: firstText(doc, l.company),
+		Location:           firstText(doc, l.location),
+		EmploymentType:     firstText(doc, l.employmentType),
+		DatePosted:         firstText(doc, l.datePosted),
+	}
+	// greenhouse and others render the company as "at Acme"
+	posting.HiringOrganization = strings.TrimPrefix(posting.HiringOrganization, "at ")
+
+	for _, sel := range l.remove {
+		doc.Find(sel).Remove()
+	}
+
+	var body strings.Builder
+	for _, sel := range l.body {
+		doc.Find(sel).Each(func(i int, s *goquery.Selection) {
+			if html, err := goquery.OuterHtml(s); err == nil {
+				body.WriteString(html)
+			}
+		})
+		if body.Len() > 0 {
+			break
+		}
+	}
+	return body.String(), posting
+}
+
+// firstText falls back to the content attribute for microdata <meta> tags.
+func firstText(doc *goquery.Document, selectors []string) string {
+	for _, sel := range selectors {
+		s := doc.Find(sel).First()
+		if text := cleanText(s.Text()); text != "" {
+			return text
+		}
+		if content := cleanText(s.AttrOr("content", "")); content != "" {
+			return content
+		}
+	}
+	return ""
+}
+
+// A link to greenhouse.io is no marker, company career pages link there to apply, and
+// #content would then pass off the whole careers page as the posting.
+var greenhouse = &siteLayout{
+	name:     "greenhouse",
+	hosts:    []string{"greenhouse.io"},
+	markers:  []string{"#app_body #header .app-title", ".job__description"},
+	remove:   []string{"#application", "#application_form", ".application--form", ".application--container", "#logo", ".job-board-listings"},
+	body:     []string{".job__description", "#content"},
+	title:    []string{".job__title h1", "#header .app-title", "h1"},
+	company:  []string{"#header .company-name", ".job__header .company-name"},
+	location: []string{".job__location", "#header .location"},
+}
+
+var lever = &siteLayout{
+	name:           "lever",
+	hosts:          []string{"lever.co"},
+	markers:        []string{".posting-headline", ".posting-categories"},
+	remove:         []string{".posting-apply", ".postings-btn-wrapper", ".application-page", ".main-footer", ".main-header"},
+	body:           []string{".posting-page .section-wrapper.page-full-width .section.page-centered", ".posting-page .content"},
+	title:          []string{".posting-headline h2"},
+	location:       []string{".posting-categories .location", ".posting-categories .sort-by-location"},
+	employmentType: []string{".posting-categories .commitment", ".posting-categories .sort-by-commitment"},
+}
+
+// Ashby builds class names from CSS modules, only the readable prefix is stable
+var ashby = &siteLayout{
+	name:           "ashby",
+	hosts:          []string{"ashbyhq.com"},
+	markers:        []string{`[class*="ashby-job-posting"]`, `[class*="_jobPostingRoot"]`},
+	remove:         []string{`[class*="_applicationForm"]`, `[class*="ashby-application-form"]`, `[class*="_navRoot"]`, `[class*="_footer"]`},
+	body:           []string{`[class*="_descriptionText"]`, `[class*="ashby-job-posting-description"]`},
+	title:          []string{`[class*="ashby-job-posting-heading"]`, `h1[class*="_title"]`, "h1"},
+	location:       []string{`[class*="_section"]:contains("Location") p`, `[class*="_section"]:contains("Location") span + *`},
+	employmentType: []string{`[class*="_section"]:contains("Employment Type") p`},
+}
+
+var workday = &siteLayout{
+	name:           "workday",
+	hosts:          []string{"myworkdayjobs.com", "myworkdaysite.com", "workday.com"},
+	markers:        []string{`[data-automation-id="jobPostingDescription"]`},
+	remove:         []string{`[data-automation-id="similarJobs"]`, `[data-automation-id="jobSidebar"]`, `[data-automation-id="applyButtonPanel"]`, `[data-automation-id="footerContainer"]`},
+	body:           []string{`[data-automation-id="jobPostingDescription"]`},
+	title:          []string{`[data-automation-id="jobPostingHeader"]`},
+	location:       []string{`[data-automation-id="locations"] dd`, `[data-automation-id="locations"]`},
+	employmentType: []string{`[data-automation-id="time"] dd`, `[data-automation-id="time"]`},
+	datePosted:     []string{`[data-automation-id="postedOn"] dd`, `[data-automation-id="postedOn"]`},
+}
+
+var smartRecruiters = &siteLayout{
+	name:           "smartrecruiters",
+	hosts:          []string{"smartrecruiters.com"},
+	markers:        []string{"#st-jobDescription", ".job-sections"},
+	remove:         []string{".sticky-bar", ".js-apply", ".other-jobs", ".related-jobs", "#st-apply", ".footer"},
+	body:           []string{"#st-jobDescription, #st-qualifications, #st-additionalInformation", `[itemprop="description"]`, ".job-sections"},
+	title:          []string{"h1.job-title", `[itemprop="title"]`},
+	company:        []string{`[itemprop="hiringOrganization"] [itemprop="name"]`},
+	location:       []string{"spl-job-location", `[itemprop="jobLocation"]`, ".job-detail-location"},
+	employmentType: []string{`[itemprop="employmentType"]`},
+	datePosted:     []string{`[itemprop="datePosted"]`},
+}
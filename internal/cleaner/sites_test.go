@@ -0,0 +1,148 @@
+package cleaner
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+func TestCleanPageSites(t *testing.T) {
+	tests := []struct {
+		fixture string
+		url     string
+		site    string
+		posting *types.JobPosting
+		// contains and excludes are checked against the cleaned text
+		contains []string
+		excludes []string
+	}{
+		{
+			fixture:  "greenhouse.html",
+			url:      "https://boards.greenhouse.io/acme/jobs/123",
+			site:     "greenhouse",
+			posting:  &types.JobPosting{Title: "Backend Engineer", HiringOrganization: "Acme", Location: "Berlin, Germany"},
+			contains: []string{"build our payments platform", "5+ years of experience with Go"},
+			excludes: []string{"First Name"},
+		},
+		{
+			// pasted, recognised by its markup alone
+			fixture:  "greenhouse.html",
+			site:     "greenhouse",
+			posting:  &types.JobPosting{Title: "Backend Engineer", HiringOrganization: "Acme", Location: "Berlin, Germany"},
+			contains: []string{"build our payments platform"},
+			excludes: []string{"First Name"},
+		},
+		{
+			fixture:  "lever.html",
+			url:      "https://jobs.lever.co/initech/abc",
+			site:     "lever",
+			posting:  &types.JobPosting{Title: "Site Reliability Engineer", Location: "Remote - US", EmploymentType: "Full-time"},
+			contains: []string{"Keep our infrastructure running.", "Kubernetes in production"},
+			excludes: []string{"Apply for this job", "powered by Lever"},
+		},
+		{
+			fixture:  "ashby.html",
+			url:      "https://jobs.ashbyhq.com/globex/1",
+			site:     "ashby",
+			posting:  &types.JobPosting{Title: "Data Engineer", Location: "New York", EmploymentType: "Full time"},
+			contains: []string{"Own our data pipelines.", "Spark and Airflow"},
+			excludes: []string{"Resume", "Powered by Ashby"},
+		},
+		{
+			fixture:  "workday.html",
+			url:      "https://globex.wd5.myworkdayjobs.com/en-US/careers/job/123",
+			site:     "workday",
+			posting:  &types.JobPosting{Title: "Senior Platform Engineer", Location: "Austin, TX", EmploymentType: "Full time", DatePosted: "Posted 3 Days Ago"},
+			contains: []string{"Run the platform team's services.", "Terraform"},
+			excludes: []string{"Platform Engineer II"},
+		},
+		{
+			fixture: "smartrecruiters.html",
+			url:     "https://jobs.smartrecruiters.com/Umbrella/123",
+			site:    "smartrecruiters",
+			posting: &types.JobPosting{
+				Title: "Mobile Developer", HiringOrganization: "Umbrella", Location: "Lisbon, Portugal",
+				EmploymentType: "Full-time", DatePosted: "2025-03-04",
+			},
+			contains: []string{"Ship our iOS app.", "Swift", "Hybrid, two days a week."},
+			excludes: []string{"Android Developer", "I'm interested"},
+		},
+		{
+			// links to greenhouse to apply but is no greenhouse page
+			fixture:  "careers.html",
+			url:      "https://hooli.example/careers",
+			site:     "",
+			contains: []string{"Join us in making the world a better place."},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture+" "+tt.url, func(t *testing.T) {
+			page, err := os.ReadFile(filepath.Join("testdata", tt.fixture))
+			if err != nil {
+				t.Fatal(err)
+			}
+			result := NewCleaner().CleanPage(string(page), tt.url)
+			if result.Site != tt.site {
+				t.Errorf("Site = %q, want %q", result.Site, tt.site)
+			}
+			switch {
+			case tt.posting == nil && result.Posting != nil:
+				t.Errorf("Posting = %+v, want none", *result.Posting)
+			case tt.posting != nil && result.Posting == nil:
+				t.Errorf("Posting = nil, want %+v", *tt.posting)
+			case tt.posting != nil && *result.Posting != *tt.posting:
+				t.Errorf("Posting = %+v\nwant      %+v", *result.Posting, *tt.posting)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(result.Text, want) {
+					t.Errorf("Text is missing %q:\n%s", want, result.Text)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if strings.Contains(result.Text, unwanted) {
+					t.Errorf("Text contains %q:\n%s", unwanted, result.Text)
+				}
+			}
+		})
+	}
+}
+
+func TestSiteMatchHost(t *testing.T) {
+	tests := []struct {
+		url  string
+		site string
+	}{
+		{"https://boards.greenhouse.io/acme/jobs/1", "greenhouse"},
+		{"https://job-boards.eu.greenhouse.io/acme/jobs/1", "greenhouse"},
+		{"https://notgreenhouse.io/jobs/1", ""},
+		{"https://jobs.lever.co/acme/1", "lever"},
+		{"https://jobs.ashbyhq.com/acme", "ashby"},
+		{"https://acme.wd1.myworkdayjobs.com/x", "workday"},
+		{"https://careers.smartrecruiters.com/acme", "smartrecruiters"},
+		{"https://example.com/jobs/1", ""},
+	}
+	// no board markup, so only the host can match
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<html><body><p>Build things.</p></body></html>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		u, err := url.Parse(tt.url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		name := ""
+		if site := matchSite(u, doc); site != nil {
+			name = site.Name()
+		}
+		if name != tt.site {
+			t.Errorf("%s: matched %q, want %q", tt.url, name, tt.site)
+		}
+	}
+}
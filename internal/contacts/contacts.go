@@ -0,0 +1,189 @@
+package contacts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"slices"
+	"strings"
+
+	"github.com/p-shah256/tracker/internal/storage"
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+var ErrInvalid = errors.New("invalid contact")
+
+var roles = []types.ContactRole{
+	types.ContactRecruiter,
+	types.ContactHiringManager,
+	types.ContactReferrer,
+	types.ContactOther,
+}
+
+var kinds = []types.InteractionKind{
+	types.InteractionEmail,
+	types.InteractionCall,
+	types.InteractionMeeting,
+	types.InteractionMessage,
+	types.InteractionNote,
+}
+
+func Roles() []types.ContactRole {
+	return slices.Clone(roles)
+}
+
+func Kinds() []types.InteractionKind {
+	return slices.Clone(kinds)
+}
+
+// Service keeps the people behind applications and what was said to them.
+type Service struct {
+	repo storage.Repository
+}
+
+func NewService(repo storage.Repository) *Service {
+	return &Service{repo: repo}
+}
+
+func (s *Service) Create(ctx context.Context, c *types.Contact) error {
+	if err := validate(c); err != nil {
+		return err
+	}
+	return s.repo.CreateContact(ctx, c)
+}
+
+func (s *Service) Get(ctx context.Context, id string) (*types.Contact, error) {
+	return s.repo.GetContact(ctx, id)
+}
+
+func (s *Service) List(ctx context.Context, filter storage.ContactFilter) ([]types.Contact, error) {
+	return s.repo.ListContacts(ctx, filter)
+}
+
+func (s *Service) Update(ctx context.Context, c *types.Contact) error {
+	if err := validate(c); err != nil {
+		return err
+	}
+	return s.repo.UpdateContact(ctx, c)
+}
+
+func (s *Service) Delete(ctx context.Context, id string) error {
+	return s.repo.DeleteContact(ctx, id)
+}
+
+// AddToApplication links a contact to an application, creating it first unless it has
+// an ID. A new contact works at the application's company unless it says otherwise.
+// The contact and the application have to belong to the same user.
+func (s *Service) AddToApplication(ctx context.Context, app *types.Application, c *types.Contact) error {
+	if c.ID == "" {
+		if strings.TrimSpace(c.Company) == "" {
+			c.Company = app.Company
+		}
+		if c.UserID == "" {
+			c.UserID = app.UserID
+		}
+		if err := sameUser(c, app); err != nil {
+			return err
+		}
+		if err := s.Create(ctx, c); err != nil {
+			return err
+		}
+	} else {
+		existing, err := s.repo.GetContact(ctx, c.ID)
+		if err != nil {
+			return err
+		}
+		if err := sameUser(existing, app); err != nil {
+			return err
+		}
+	}
+	if err := s.repo.LinkContact(ctx, app.ID, c.ID); err != nil {
+		return err
+	}
+	linked, err := s.repo.GetContact(ctx, c.ID)
+	if err != nil {
+		return err
+	}
+	*c = *linked
+	return nil
+}
+
+func (s *Service) Unlink(ctx context.Context, applicationID, contactID string) error {
+	return s.repo.UnlinkContact(ctx, applicationID, contactID)
+}
+
+// Log records an interaction. One about an application links the contact to it too, if
+// it wasn't already.
+func (s *Service) Log(ctx context.Context, i *types.Interaction) error {
+	i.Summary = strings.TrimSpace(i.Summary)
+	if i.Kind == "" {
+		i.Kind = types.InteractionNote
+	}
+	switch {
+	case !slices.Contains(kinds, i.Kind):
+		return fmt.Errorf("%w: kind must be one of %v", ErrInvalid, kinds)
+	case i.Summary == "":
+		return fmt.Errorf("%w: summary is required", ErrInvalid)
+	}
+
+	if i.ApplicationID != "" {
+		c, err := s.repo.GetContact(ctx, i.ContactID)
+		if err != nil {
+			return err
+		}
+		app, err := s.repo.GetApplication(ctx, i.ApplicationID)
+		if err != nil {
+			return err
+		}
+		if err := sameUser(c, app); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.CreateInteraction(ctx, i); err != nil {
+		return err
+	}
+	if i.ApplicationID != "" {
+		return s.repo.LinkContact(ctx, i.ApplicationID, i.ContactID)
+	}
+	return nil
+}
+
+// sameUser keeps contacts with their own user's applications. Either side without a
+// user is shared.
+func sameUser(c *types.Contact, app *types.Application) error {
+	if c.UserID != "" && app.UserID != "" && c.UserID != app.UserID {
+		return fmt.Errorf("%w: the contact and application %s belong to different users", ErrInvalid, app.ID)
+	}
+	return nil
+}
+
+func (s *Service) Interactions(ctx context.Context, filter storage.InteractionFilter) ([]types.Interaction, error) {
+	return s.repo.ListInteractions(ctx, filter)
+}
+
+func (s *Service) DeleteInteraction(ctx context.Context, id string) error {
+	return s.repo.DeleteInteraction(ctx, id)
+}
+
+func validate(c *types.Contact) error {
+	c.Name = strings.TrimSpace(c.Name)
+	c.Company = strings.TrimSpace(c.Company)
+	c.Email = strings.TrimSpace(c.Email)
+	if c.Role == "" {
+		c.Role = types.ContactOther
+	}
+	switch {
+	case c.Name == "":
+		return fmt.Errorf("%w: name is required", ErrInvalid)
+	case !slices.Contains(roles, c.Role):
+		return fmt.Errorf("%w: role must be one of %v", ErrInvalid, roles)
+	}
+	if c.Email != "" {
+		if _, err := mail.ParseAddress(c.Email); err != nil {
+			return fmt.Errorf("%w: email %q isn't an address", ErrInvalid, c.Email)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package contacts
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/p-shah256/tracker/internal/storage"
+	"github.com/p-shah256/tracker/internal/storage/storagetest"
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+func newTestService(t *testing.T) (*Service, storage.Repository) {
+	store := storagetest.Open(t)
+	return NewService(store), store
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		contact types.Contact
+		ok      bool
+	}{
+		{"name only", types.Contact{Name: " Dana Lee "}, true},
+		{"full", types.Contact{Name: "Dana Lee", Role: types.ContactRecruiter, Email: "dana@example.com"}, true},
+		{"no name", types.Contact{Name: "  ", Email: "dana@example.com"}, false},
+		{"unknown role", types.Contact{Name: "Dana Lee", Role: "friend"}, false},
+		{"bad email", types.Contact{Name: "Dana Lee", Email: "dana at example"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.contact
+			err := validate(&c)
+			if (err == nil) != tt.ok {
+				t.Fatalf("validate() = %v, want ok %v", err, tt.ok)
+			}
+			if err != nil && !errors.Is(err, ErrInvalid) {
+				t.Errorf("error %v isn't ErrInvalid", err)
+			}
+			if err == nil && (c.Name != "Dana Lee" || c.Role == "") {
+				t.Errorf("contact wasn't normalized: %+v", c)
+			}
+		})
+	}
+}
+
+func TestAddToApplication(t *testing.T) {
+	ctx := context.Background()
+	svc, store := newTestService(t)
+	app := &types.Application{UserID: "u1", Company: "Acme", Position: "Engineer", Status: types.StatusApplied}
+	if err := store.CreateApplication(ctx, app); err != nil {
+		t.Fatal(err)
+	}
+
+	c := &types.Contact{Name: "Dana Lee", Role: types.ContactRecruiter}
+	if err := svc.AddToApplication(ctx, app, c); err != nil {
+		t.Fatal(err)
+	}
+	if c.ID == "" || c.Company != "Acme" || c.UserID != "u1" || !slices.Equal(c.ApplicationIDs, []string{app.ID}) {
+		t.Errorf("new contact = %+v, want it at Acme for u1 and linked", c)
+	}
+
+	// linking an existing contact again changes nothing
+	if err := svc.AddToApplication(ctx, app, &types.Contact{ID: c.ID}); err != nil {
+		t.Fatal(err)
+	}
+	linked, err := svc.List(ctx, storage.ContactFilter{ApplicationID: app.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(linked) != 1 {
+		t.Errorf("got %d linked contacts, want 1", len(linked))
+	}
+}
+
+func TestLog(t *testing.T) {
+	ctx := context.Background()
+	svc, store := newTestService(t)
+	app := &types.Application{UserID: "u1", Company: "Acme", Position: "Engineer", Status: types.StatusApplied}
+	if err := store.CreateApplication(ctx, app); err != nil {
+		t.Fatal(err)
+	}
+	c := &types.Contact{Name: "Dana Lee"}
+	if err := svc.Create(ctx, c); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		interaction types.Interaction
+		ok          bool
+	}{
+		{"no summary", types.Interaction{ContactID: c.ID, Summary: "  "}, false},
+		{"unknown kind", types.Interaction{ContactID: c.ID, Kind: "fax", Summary: "Sent my CV"}, false},
+		{"note by default", types.Interaction{ContactID: c.ID, Summary: "Met at a meetup"}, true},
+		{"about an application", types.Interaction{ContactID: c.ID, ApplicationID: app.ID, Kind: types.InteractionCall, Summary: "Phone screen booked"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := tt.interaction
+			err := svc.Log(ctx, &i)
+			if (err == nil) != tt.ok {
+				t.Fatalf("Log() = %v, want ok %v", err, tt.ok)
+			}
+			if err != nil && !errors.Is(err, ErrInvalid) {
+				t.Errorf("error %v isn't ErrInvalid", err)
+			}
+		})
+	}
+
+	got, err := svc.Get(ctx, c.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Interactions != 2 || got.LastContactedAt == nil {
+		t.Errorf("contact = %+v, want 2 interactions and a last contact", got)
+	}
+	// the call about the application linked the contact to it
+	if !slices.Equal(got.ApplicationIDs, []string{app.ID}) {
+		t.Errorf("application IDs = %v, want %v", got.ApplicationIDs, []string{app.ID})
+	}
+	interactions, err := svc.Interactions(ctx, storage.InteractionFilter{ContactID: c.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(interactions) != 2 || interactions[0].Kind == "" {
+		t.Errorf("interactions = %+v", interactions)
+	}
+}
+
+func TestContactsStayWithTheirUser(t *testing.T) {
+	ctx := context.Background()
+	svc, store := newTestService(t)
+	theirs := &types.Application{UserID: "grace", Company: "Acme", Position: "Engineer", Status: types.StatusApplied}
+	if err := store.CreateApplication(ctx, theirs); err != nil {
+		t.Fatal(err)
+	}
+	mine := &types.Contact{UserID: "ada", Name: "Dana Lee"}
+	if err := svc.Create(ctx, mine); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := svc.AddToApplication(ctx, theirs, &types.Contact{ID: mine.ID}); !errors.Is(err, ErrInvalid) {
+		t.Errorf("linking to another user's application error = %v, want ErrInvalid", err)
+	}
+	if err := svc.AddToApplication(ctx, theirs, &types.Contact{UserID: "ada", Name: "Sam Roe"}); !errors.Is(err, ErrInvalid) {
+		t.Errorf("creating a contact on another user's application error = %v, want ErrInvalid", err)
+	}
+	err := svc.Log(ctx, &types.Interaction{ContactID: mine.ID, ApplicationID: theirs.ID, Summary: "Asked about the role"})
+	if !errors.Is(err, ErrInvalid) {
+		t.Errorf("logging about another user's application error = %v, want ErrInvalid", err)
+	}
+
+	linked, err := svc.List(ctx, storage.ContactFilter{ApplicationID: theirs.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(linked) != 0 {
+		t.Errorf("another user's application has contacts %+v", linked)
+	}
+	got, err := svc.Get(ctx, mine.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Interactions != 0 {
+		t.Errorf("the rejected interaction was saved")
+	}
+}
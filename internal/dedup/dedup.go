@@ -0,0 +1,181 @@
+package dedup
+
+import (
+	"hash/fnv"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const (
+	// shingleSize words per shingle, long enough that boilerplate phrases shared by every
+	// posting ("experience with", "you will") don't make unrelated jobs look alike
+	shingleSize = 5
+	numHashes   = 128
+
+	// DescriptionThreshold is the estimated Jaccard similarity from which two descriptions
+	// are taken to be the same posting, whatever the company and title say
+	DescriptionThreshold = 0.85
+	// RoleThreshold is enough when company and title match as well: aggregators trim,
+	// reorder and append their own text to the posting
+	RoleThreshold = 0.5
+)
+
+// seeds make numHashes hash functions out of one, fixed so signatures stay comparable
+// between runs.
+var seeds = func() [numHashes]uint64 {
+	var s [numHashes]uint64
+	x := uint64(0x9e3779b97f4a7c15)
+	for i := range s {
+		x = mix(x + uint64(i))
+		s[i] = x
+	}
+	return s
+}()
+
+// Signature is the MinHash of a text's word shingles. Nil means the text was too short
+// to say anything about.
+type Signature []uint64
+
+// NewSignature hashes a cleaned job description (CleanHTML output).
+func NewSignature(text string) Signature {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r > 127 || r == '+' || r == '#')
+	})
+	if len(words) < shingleSize {
+		return nil
+	}
+
+	sig := make(Signature, numHashes)
+	for i := range sig {
+		sig[i] = ^uint64(0)
+	}
+	for i := 0; i+shingleSize <= len(words); i++ {
+		h := fnv.New64a()
+		for _, word := range words[i : i+shingleSize] {
+			h.Write([]byte(word))
+			h.Write([]byte{' '})
+		}
+		shingle := h.Sum64()
+		for j, seed := range seeds {
+			sig[j] = min(sig[j], mix(shingle^seed))
+		}
+	}
+	return sig
+}
+
+// Similarity estimates the Jaccard similarity of the two texts' shingle sets, 0 when
+// either is empty.
+func (s Signature) Similarity(other Signature) float64 {
+	if len(s) == 0 || len(s) != len(other) {
+		return 0
+	}
+	same := 0
+	for i := range s {
+		if s[i] == other[i] {
+			same++
+		}
+	}
+	return float64(same) / float64(len(s))
+}
+
+// mix is the splitmix64 finalizer.
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// Posting is what's compared: a job's company, title and description signature.
+type Posting struct {
+	Company   string
+	Title     string
+	Signature Signature
+}
+
+type Match struct {
+	// Similarity is the estimated description similarity, 0 when either side has none
+	Similarity  float64
+	SameCompany bool
+	SameTitle   bool
+	Duplicate   bool
+}
+
+// Compare decides whether two postings are the same job. Matching company and title is
+// enough when a description is missing, but two descriptions that clearly differ mean
+// separate openings (another team, another location) even under the same title.
+func Compare(a, b Posting) Match {
+	m := Match{
+		Similarity:  a.Signature.Similarity(b.Signature),
+		SameCompany: SameCompany(a.Company, b.Company),
+		SameTitle:   SameTitle(a.Title, b.Title),
+	}
+	switch {
+	case m.Similarity >= DescriptionThreshold:
+		m.Duplicate = true
+	case m.SameCompany && m.SameTitle:
+		m.Duplicate = a.Signature == nil || b.Signature == nil || m.Similarity >= RoleThreshold
+	}
+	return m
+}
+
+var (
+	nonWord       = regexp.MustCompile(`[^\p{L}\p{N}+#]+`)
+	companySuffix = []string{"inc", "incorporated", "llc", "ltd", "limited", "corp", "corporation", "co", "company", "gmbh", "plc", "sa", "ag", "bv"}
+	titleNoise    = []string{"remote", "hybrid", "onsite", "m", "f", "d", "w", "x"}
+	titleAliases  = map[string]string{
+		"sr": "senior", "jr": "junior", "eng": "engineer", "engr": "engineer", "dev": "developer",
+		"swe": "software engineer", "mgr": "manager", "i": "1", "ii": "2", "iii": "3", "iv": "4",
+	}
+)
+
+// SameCompany compares names ignoring case, punctuation and legal suffixes, so
+// "Acme, Inc." and "ACME" match. Empty names never match.
+func SameCompany(a, b string) bool {
+	na, nb := companyKey(a), companyKey(b)
+	return na != "" && na == nb
+}
+
+func companyKey(name string) string {
+	words := strings.Fields(nonWord.ReplaceAllString(strings.ToLower(name), " "))
+	for len(words) > 1 && slices.Contains(companySuffix, words[len(words)-1]) {
+		words = words[:len(words)-1]
+	}
+	return strings.Join(words, " ")
+}
+
+// SameTitle compares titles as sets of words after expanding common abbreviations and
+// dropping location tags, so "Sr. Software Engineer (Remote)" matches "Senior Software Engineer".
+func SameTitle(a, b string) bool {
+	ta, tb := titleWords(a), titleWords(b)
+	if len(ta) == 0 || len(ta) != len(tb) {
+		return false
+	}
+	for _, word := range ta {
+		if !slices.Contains(tb, word) {
+			return false
+		}
+	}
+	return true
+}
+
+func titleWords(title string) []string {
+	var words []string
+	for _, word := range strings.Fields(nonWord.ReplaceAllString(strings.ToLower(title), " ")) {
+		if slices.Contains(titleNoise, word) {
+			continue
+		}
+		if alias, ok := titleAliases[word]; ok {
+			word = alias
+		}
+		for _, w := range strings.Fields(word) {
+			if !slices.Contains(words, w) {
+				words = append(words, w)
+			}
+		}
+	}
+	return words
+}
@@ -0,0 +1,115 @@
+package dedup
+
+import (
+	"strings"
+	"testing"
+)
+
+const posting = `We are looking for a backend engineer to join the payments team. You will design,
+build and operate the services that move money for millions of merchants, from card
+authorization to settlement and payouts. You will work closely with product and finance,
+own your services in production, and mentor other engineers. Requirements: five years of
+experience building distributed systems in Go or Java, strong knowledge of PostgreSQL and
+Kafka, experience running services on Kubernetes, and a habit of writing clear design docs.`
+
+const otherPosting = `Our data platform team is hiring a machine learning engineer to build feature pipelines
+and model serving infrastructure. You will partner with data scientists to take models from
+notebooks to production, tune training jobs on GPUs, and keep our Spark and Airflow jobs
+healthy. Requirements: three years of Python, experience with PyTorch or TensorFlow, and
+comfort with cloud data warehouses such as BigQuery or Snowflake.`
+
+func TestSignature(t *testing.T) {
+	sig := NewSignature(posting)
+	if len(sig) != numHashes {
+		t.Fatalf("signature has %d hashes, want %d", len(sig), numHashes)
+	}
+	if NewSignature("too short to say") != nil {
+		t.Error("a text under one shingle got a signature")
+	}
+
+	tests := []struct {
+		name     string
+		text     string
+		min, max float64
+	}{
+		{"identical", posting, 1, 1},
+		{"case and punctuation", strings.ToUpper(strings.ReplaceAll(posting, ",", " ;")), 1, 1},
+		{"aggregator wrapper", "Apply now on JobBoard! " + posting + " Posted 3 days ago. 120 applicants.", DescriptionThreshold, 1},
+		{"unrelated", otherPosting, 0, 0.1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sig.Similarity(NewSignature(tt.text))
+			if got < tt.min || got > tt.max {
+				t.Errorf("Similarity = %v, want %v-%v", got, tt.min, tt.max)
+			}
+		})
+	}
+	if got := sig.Similarity(nil); got != 0 {
+		t.Errorf("Similarity(nil) = %v, want 0", got)
+	}
+}
+
+func TestSameCompany(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"Acme, Inc.", "ACME", true},
+		{"Globex Corporation", "globex corp", true},
+		{"The Company", "The Company Co", true},
+		{"Acme", "Acme Labs", false},
+		{"", "", false},
+		{"Inc", "Inc.", true},
+	}
+	for _, tt := range tests {
+		if got := SameCompany(tt.a, tt.b); got != tt.want {
+			t.Errorf("SameCompany(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSameTitle(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"Sr. Software Engineer (Remote)", "Senior Software Engineer", true},
+		{"SWE II", "Software Engineer 2", true},
+		{"Backend Engineer (m/f/d)", "backend engineer", true},
+		{"Engineer, Backend", "Backend Engineer", true},
+		{"Senior Backend Engineer", "Backend Engineer", false},
+		{"C++ Developer", "C# Developer", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		if got := SameTitle(tt.a, tt.b); got != tt.want {
+			t.Errorf("SameTitle(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	sig, other := NewSignature(posting), NewSignature(otherPosting)
+	// the same posting with a different closing paragraph, as aggregators tend to
+	trimmed := NewSignature(posting[:len(posting)*2/3] + " Benefits include equity, health insurance and a learning budget for every engineer on the team.")
+	tests := []struct {
+		name string
+		a, b Posting
+		want bool
+	}{
+		{"same description, different title", Posting{"Acme", "Backend Engineer", sig}, Posting{"Initech", "Payments Engineer", sig}, true},
+		{"same role, no descriptions", Posting{"Acme", "Backend Engineer", nil}, Posting{"Acme Inc", "Backend Engineer", nil}, true},
+		{"same role, edited description", Posting{"Acme", "Backend Engineer", sig}, Posting{"Acme", "Backend Engineer", trimmed}, true},
+		{"same title, another opening", Posting{"Acme", "Backend Engineer", sig}, Posting{"Acme", "Backend Engineer", other}, false},
+		{"same title, another company", Posting{"Acme", "Backend Engineer", nil}, Posting{"Globex", "Backend Engineer", nil}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Compare(tt.a, tt.b)
+			if m.Duplicate != tt.want {
+				t.Errorf("Compare = %+v, want duplicate %v", m, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package diff
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+const (
+	OpEqual  = "equal"
+	OpInsert = "insert"
+	OpDelete = "delete"
+)
+
+// Words diffs two texts word by word. Whitespace is kept in the ops, so joining the
+// equal and delete texts gives back a, and joining the equal and insert texts gives back b.
+func Words(a, b string) []types.DiffOp {
+	return cleanup(merge(myers(tokenize(a), tokenize(b))))
+}
+
+// Count returns the number of words inserted and deleted.
+func Count(ops []types.DiffOp) (inserted, deleted int) {
+	for _, op := range ops {
+		switch op.Op {
+		case OpInsert:
+			inserted += len(strings.Fields(op.Text))
+		case OpDelete:
+			deleted += len(strings.Fields(op.Text))
+		}
+	}
+	return inserted, deleted
+}
+
+// tokenize splits text into alternating runs of words and whitespace.
+func tokenize(s string) []string {
+	var tokens []string
+	start := 0
+	for i, r := range s {
+		if i > start && unicode.IsSpace(r) != isSpaceAt(s, start) {
+			tokens = append(tokens, s[start:i])
+			start = i
+		}
+	}
+	if start < len(s) {
+		tokens = append(tokens, s[start:])
+	}
+	return tokens
+}
+
+func isSpaceAt(s string, i int) bool {
+	for _, r := range s[i:] {
+		return unicode.IsSpace(r)
+	}
+	return false
+}
+
+// Past these limits myers gives up on a minimal diff and replaces the whole changed middle:
+// the trace grows with the square of the edit distance, and two unrelated documents aren't
+// worth a word-by-word diff anyway.
+const (
+	maxTokens = 20000
+	maxEdits  = 1000
+)
+
+// myers finds the shortest edit script from a to b (Myers, "An O(ND) Difference
+// Algorithm and Its Variations"), one op per token.
+func myers(a, b []string) []types.DiffOp {
+	// the common prefix and suffix don't need searching
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var ops []types.DiffOp
+	for _, t := range a[:prefix] {
+		ops = append(ops, types.DiffOp{Op: OpEqual, Text: t})
+	}
+	middle, ok := shortestEdit(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])
+	if !ok {
+		middle = replace(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])
+	}
+	ops = append(ops, middle...)
+	for _, t := range a[len(a)-suffix:] {
+		ops = append(ops, types.DiffOp{Op: OpEqual, Text: t})
+	}
+	return ops
+}
+
+// shortestEdit is the Myers search itself, ok is false when a and b are past the limits.
+func shortestEdit(a, b []string) ([]types.DiffOp, bool) {
+	n, m := len(a), len(b)
+	if n+m > maxTokens {
+		return nil, false
+	}
+	offset := min(n+m, maxEdits) + 1
+	v := make([]int, 2*offset+1)
+
+	// trace[d] is v[-d..d] after step d, walked backwards to recover the path
+	var trace [][]int
+search:
+	for d := 0; ; d++ {
+		if d > maxEdits {
+			return nil, false
+		}
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				trace = append(trace, slices.Clone(v[offset-d:offset+d+1]))
+				break search
+			}
+		}
+		trace = append(trace, slices.Clone(v[offset-d:offset+d+1]))
+	}
+
+	var ops []types.DiffOp
+	x, y := n, m
+	for d := len(trace) - 1; d > 0; d-- {
+		prev := trace[d-1]
+		at := func(k int) int { return prev[k+d-1] }
+		k := x - y
+		prevK := k - 1
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			ops = append(ops, types.DiffOp{Op: OpEqual, Text: a[x-1]})
+			x--
+			y--
+		}
+		if x == prevX {
+			ops = append(ops, types.DiffOp{Op: OpInsert, Text: b[y-1]})
+			y--
+		} else {
+			ops = append(ops, types.DiffOp{Op: OpDelete, Text: a[x-1]})
+			x--
+		}
+	}
+	for x > 0 && y > 0 {
+		ops = append(ops, types.DiffOp{Op: OpEqual, Text: a[x-1]})
+		x--
+		y--
+	}
+	slices.Reverse(ops)
+	return ops, true
+}
+
+// replace deletes all of a and inserts all of b.
+func replace(a, b []string) []types.DiffOp {
+	ops := make([]types.DiffOp, 0, len(a)+len(b))
+	for _, t := range a {
+		ops = append(ops, types.DiffOp{Op: OpDelete, Text: t})
+	}
+	for _, t := range b {
+		ops = append(ops, types.DiffOp{Op: OpInsert, Text: t})
+	}
+	return ops
+}
+
+// merge joins neighbouring ops of the same kind, and puts deletes before inserts
+// within a change so it reads as "this was replaced by that".
+func merge(ops []types.DiffOp) []types.DiffOp {
+	var out []types.DiffOp
+	for i := 0; i < len(ops); {
+		if ops[i].Op == OpEqual {
+			j := i
+			var text strings.Builder
+			for ; j < len(ops) && ops[j].Op == OpEqual; j++ {
+				text.WriteString(ops[j].Text)
+			}
+			out = append(out, types.DiffOp{Op: OpEqual, Text: text.String()})
+			i = j
+			continue
+		}
+		j := i
+		var deleted, inserted strings.Builder
+		for ; j < len(ops) && ops[j].Op != OpEqual; j++ {
+			if ops[j].Op == OpDelete {
+				deleted.WriteString(ops[j].Text)
+			} else {
+				inserted.WriteString(ops[j].Text)
+			}
+		}
+		if deleted.Len() > 0 {
+			out = append(out, types.DiffOp{Op: OpDelete, Text: deleted.String()})
+		}
+		if inserted.Len() > 0 {
+			out = append(out, types.DiffOp{Op: OpInsert, Text: inserted.String()})
+		}
+		i = j
+	}
+	return out
+}
+
+// cleanup folds a lone space matched between two changes into them, otherwise a
+// rewritten sentence shows up as a word-by-word patchwork held together by spaces.
+func cleanup(ops []types.DiffOp) []types.DiffOp {
+	changed := true
+	for changed {
+		changed = false
+		for i := 1; i < len(ops)-1; i++ {
+			if ops[i].Op != OpEqual || strings.TrimSpace(ops[i].Text) != "" ||
+				ops[i-1].Op == OpEqual || ops[i+1].Op == OpEqual {
+				continue
+			}
+			space := ops[i].Text
+			expanded := []types.DiffOp{
+				{Op: OpDelete, Text: space},
+				{Op: OpInsert, Text: space},
+			}
+			ops = merge(slices.Concat(ops[:i], expanded, ops[i+1:]))
+			changed = true
+			break
+		}
+	}
+	return ops
+}
@@ -0,0 +1,127 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+// sides rebuilds both texts from the ops.
+func sides(ops []types.DiffOp) (string, string) {
+	var a, b strings.Builder
+	for _, op := range ops {
+		if op.Op != OpInsert {
+			a.WriteString(op.Text)
+		}
+		if op.Op != OpDelete {
+			b.WriteString(op.Text)
+		}
+	}
+	return a.String(), b.String()
+}
+
+func format(ops []types.DiffOp) string {
+	var parts []string
+	for _, op := range ops {
+		switch op.Op {
+		case OpInsert:
+			parts = append(parts, "+"+op.Text)
+		case OpDelete:
+			parts = append(parts, "-"+op.Text)
+		default:
+			parts = append(parts, op.Text)
+		}
+	}
+	return strings.Join(parts, "|")
+}
+
+func TestWords(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{"equal", "Built the billing service", "Built the billing service", "Built the billing service"},
+		{"empty", "", "", ""},
+		{"from nothing", "", "Built it", "+Built it"},
+		{"to nothing", "Built it", "", "-Built it"},
+		{"one word", "Built the billing service", "Built the payments service", "Built the |-billing|+payments| service"},
+		{"insert", "Cut latency", "Cut p99 latency", "Cut |+p99 |latency"},
+		{"whitespace only", "Cut  latency", "Cut latency", "Cut|-  |+ |latency"},
+		{
+			"replaced run reads as one change",
+			"Led a team of engineers on the billing rewrite",
+			"Mentored two new hires during the billing rewrite",
+			"-Led a team of engineers on|+Mentored two new hires during| the billing rewrite",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ops := Words(tt.a, tt.b)
+			if got := format(ops); got != tt.want {
+				t.Errorf("Words = %q, want %q", got, tt.want)
+			}
+			if a, b := sides(ops); a != tt.a || b != tt.b {
+				t.Errorf("ops rebuild %q and %q", a, b)
+			}
+		})
+	}
+}
+
+func TestWordsPastLimits(t *testing.T) {
+	var a, b []string
+	for i := range maxEdits {
+		a = append(a, fmt.Sprintf("a%d", i))
+		b = append(b, fmt.Sprintf("b%d", i))
+	}
+	before := "Summary: " + strings.Join(a, " ") + " end"
+	after := "Summary: " + strings.Join(b, " ") + " end"
+	ops := Words(before, after)
+	if got, want := format(ops), "Summary: |-"+strings.Join(a, " ")+"|+"+strings.Join(b, " ")+"| end"; got != want {
+		t.Errorf("Words = %.80q..., want the middle replaced", got)
+	}
+
+	huge := strings.Repeat("word ", maxTokens)
+	ops = Words(huge, huge+"more")
+	if x, y := sides(ops); x != huge || y != huge+"more" {
+		t.Error("ops don't rebuild the texts")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	ops := []types.DiffOp{
+		{Op: OpEqual, Text: "a"}, {Op: OpEqual, Text: " "},
+		{Op: OpInsert, Text: "x"}, {Op: OpDelete, Text: "b"}, {Op: OpInsert, Text: " "}, {Op: OpDelete, Text: " "},
+		{Op: OpEqual, Text: "c"},
+	}
+	if got, want := format(merge(ops)), "a |-b |+x |c"; got != want {
+		t.Errorf("merge = %q, want %q", got, want)
+	}
+}
+
+func TestCleanup(t *testing.T) {
+	ops := []types.DiffOp{
+		{Op: OpDelete, Text: "old"}, {Op: OpInsert, Text: "new"},
+		{Op: OpEqual, Text: " "},
+		{Op: OpDelete, Text: "words"}, {Op: OpInsert, Text: "text"},
+		{Op: OpEqual, Text: " here"},
+	}
+	if got, want := format(cleanup(ops)), "-old words|+new text| here"; got != want {
+		t.Errorf("cleanup = %q, want %q", got, want)
+	}
+
+	// a space next to an unchanged word stays
+	ops = []types.DiffOp{{Op: OpEqual, Text: "a"}, {Op: OpEqual, Text: " "}, {Op: OpInsert, Text: "b"}}
+	if got := format(cleanup(ops)); got != "a| |+b" {
+		t.Errorf("cleanup = %q", got)
+	}
+}
+
+func TestCount(t *testing.T) {
+	inserted, deleted := Count(Words("Built the billing service", "Rebuilt the billing service in Go"))
+	if inserted != 3 || deleted != 1 {
+		t.Errorf("Count = %d inserted %d deleted, want 3 and 1", inserted, deleted)
+	}
+}
@@ -0,0 +1,228 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+var (
+	ErrInvalidURL     = errors.New("invalid URL")
+	ErrBlockedAddress = errors.New("address not allowed")
+	ErrTooLarge       = errors.New("response too large")
+	ErrTooManyHops    = errors.New("too many redirects")
+	ErrUnsupported    = errors.New("unsupported content type")
+)
+
+// blockedRanges are never dialed unless Config.AllowPrivate is set. Checked at
+// connect time so a hostname that resolves (or re-resolves) to one of these is caught too.
+var blockedRanges = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("10.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("127.0.0.0/8"),
+	netip.MustParsePrefix("169.254.0.0/16"),
+	netip.MustParsePrefix("172.16.0.0/12"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.168.0.0/16"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("224.0.0.0/4"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("::/128"),
+	netip.MustParsePrefix("::1/128"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	// Teredo and 6to4 tunnel to an embedded IPv4 address
+	netip.MustParsePrefix("2001::/32"),
+	netip.MustParsePrefix("2002::/16"),
+	netip.MustParsePrefix("fc00::/7"),
+	netip.MustParsePrefix("fe80::/10"),
+	netip.MustParsePrefix("ff00::/8"),
+}
+
+type Config struct {
+	Timeout      time.Duration
+	MaxBytes     int64
+	MaxRedirects int
+	// AllowPrivate disables the private range check, only meant for tests against httptest servers
+	AllowPrivate bool
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Timeout:      15 * time.Second,
+		MaxBytes:     2 << 20,
+		MaxRedirects: 5,
+	}
+}
+
+type Page struct {
+	// URL is where the content was actually served from, after redirects
+	URL         string
+	ContentType string
+	Body        string
+}
+
+type Fetcher struct {
+	client   *http.Client
+	maxBytes int64
+}
+
+func New(cfg Config) *Fetcher {
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+	}
+	if !cfg.AllowPrivate {
+		dialer.Control = blockPrivate
+	}
+
+	transport := &http.Transport{
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		TLSHandshakeTimeout:   5 * time.Second,
+		ResponseHeaderTimeout: cfg.Timeout,
+		MaxIdleConns:          10,
+		IdleConnTimeout:       30 * time.Second,
+	}
+
+	client := &http.Client{
+		Timeout:   cfg.Timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > cfg.MaxRedirects {
+				return fmt.Errorf("%w: stopped after %d", ErrTooManyHops, cfg.MaxRedirects)
+			}
+			return checkURL(req.URL)
+		},
+	}
+
+	return &Fetcher{
+		client:   client,
+		maxBytes: cfg.MaxBytes,
+	}
+}
+
+// Fetch downloads a job posting page. Only http(s) URLs serving HTML or plain text are accepted.
+func (f *Fetcher) Fetch(ctx context.Context, rawURL string) (*Page, error) {
+	logger := slog.With(
+		"component", "fetch",
+		"url", rawURL,
+	)
+
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidURL, err)
+	}
+	if err := checkURL(u); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidURL, err)
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; tracker/1.0)")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,text/plain;q=0.9")
+
+	startTime := time.Now()
+	resp, err := f.client.Do(req)
+	if err != nil {
+		logger.Error("fetch failed", "error", err, "duration_ms", time.Since(startTime).Milliseconds())
+		return nil, fmt.Errorf("failed to fetch %s: %w", u.Redacted(), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("failed to fetch %s: upstream returned %s", u.Redacted(), resp.Status)
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if !supportedType(contentType) {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupported, contentType)
+	}
+
+	if resp.ContentLength > f.maxBytes {
+		return nil, fmt.Errorf("%w: %d bytes, limit is %d", ErrTooLarge, resp.ContentLength, f.maxBytes)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, f.maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response from %s: %w", u.Redacted(), err)
+	}
+	if int64(len(body)) > f.maxBytes {
+		return nil, fmt.Errorf("%w: limit is %d bytes", ErrTooLarge, f.maxBytes)
+	}
+
+	logger.Info("fetched job description",
+		"final_url", resp.Request.URL.String(),
+		"status", resp.StatusCode,
+		"bytes", len(body),
+		"duration_ms", time.Since(startTime).Milliseconds())
+
+	return &Page{
+		URL:         resp.Request.URL.String(),
+		ContentType: contentType,
+		Body:        string(body),
+	}, nil
+}
+
+func checkURL(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%w: scheme must be http or https", ErrInvalidURL)
+	}
+	if u.Hostname() == "" {
+		return fmt.Errorf("%w: missing host", ErrInvalidURL)
+	}
+	if u.User != nil {
+		return fmt.Errorf("%w: credentials in URL are not allowed", ErrInvalidURL)
+	}
+	return nil
+}
+
+func blockPrivate(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, address)
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, address)
+	}
+	if isBlocked(addr) {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, addr)
+	}
+	return nil
+}
+
+func isBlocked(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, prefix := range blockedRanges {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func supportedType(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	switch mediaType {
+	case "text/html", "application/xhtml+xml", "text/plain":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package history
+
+import (
+	"github.com/p-shah256/tracker/internal/llm"
+	"github.com/p-shah256/tracker/internal/storage"
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+// Build turns the score runs against one job description (oldest first) into its score
+// history: each run with its change from the one before, and the trend overall and per section.
+func Build(job *storage.Job, runs []storage.ScoreRun) types.ScoreHistory {
+	history := types.ScoreHistory{
+		JobID:    job.RequestID,
+		Runs:     []types.ScoreHistoryRun{},
+		Sections: []types.SectionTrend{},
+	}
+	if job.Skills != nil {
+		history.Company = job.Skills.CompanyInfo.Name
+		history.Position = job.Skills.CompanyInfo.Position
+	}
+
+	var overall []float64
+	// sections are matched by name across runs, in the order they were first seen
+	sections := map[string]int{}
+	for _, run := range runs {
+		if run.Result == nil {
+			continue
+		}
+		entry := types.ScoreHistoryRun{
+			RequestID:       run.RequestID,
+			ResumeID:        run.ResumeID,
+			ResumeVersionID: run.ResumeVersionID,
+			ResumeVersion:   run.ResumeVersion,
+			Model:           run.Model,
+			PromptVersion:   run.PromptVersion,
+			OverallScore:    run.Result.OverallScore,
+			Sections:        []types.SectionScore{},
+			CreatedAt:       run.CreatedAt,
+		}
+		if len(history.Runs) > 0 {
+			previous := history.Runs[len(history.Runs)-1]
+			change := entry.OverallScore - previous.OverallScore
+			entry.Change = &change
+			entry.PromptChanged = entry.PromptVersion != previous.PromptVersion
+			entry.ModelChanged = entry.Model != previous.Model
+		}
+
+		for _, section := range run.Result.Sections {
+			entry.Sections = append(entry.Sections, types.SectionScore{Name: section.Name, Kind: section.Kind, Score: section.Score})
+
+			key := llm.SectionKey(section.Name)
+			j, ok := sections[key]
+			if !ok {
+				j = len(history.Sections)
+				sections[key] = j
+				history.Sections = append(history.Sections, types.SectionTrend{Name: section.Name, Kind: section.Kind})
+			}
+			history.Sections[j].Scores = append(history.Sections[j].Scores, types.ScorePoint{
+				RequestID:     run.RequestID,
+				ResumeVersion: run.ResumeVersion,
+				Score:         section.Score,
+				CreatedAt:     run.CreatedAt,
+			})
+		}
+
+		history.Runs = append(history.Runs, entry)
+		overall = append(overall, entry.OverallScore)
+	}
+
+	history.Overall = trend(overall)
+	for i := range history.Sections {
+		scores := make([]float64, len(history.Sections[i].Scores))
+		for j, point := range history.Sections[i].Scores {
+			scores[j] = point.Score
+		}
+		history.Sections[i].ScoreTrend = trend(scores)
+	}
+	return history
+}
+
+func trend(scores []float64) types.ScoreTrend {
+	if len(scores) == 0 {
+		return types.ScoreTrend{}
+	}
+	t := types.ScoreTrend{
+		Runs:  len(scores),
+		First: scores[0],
+		Last:  scores[len(scores)-1],
+		Min:   scores[0],
+		Max:   scores[0],
+	}
+	for _, score := range scores[1:] {
+		t.Min = min(t.Min, score)
+		t.Max = max(t.Max, score)
+	}
+	t.Change = t.Last - t.First
+	return t
+}
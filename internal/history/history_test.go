@@ -0,0 +1,52 @@
+package history
+
+import (
+	"testing"
+
+	"github.com/p-shah256/tracker/internal/storage"
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+func TestBuild(t *testing.T) {
+	run := func(id, prompt string, overall float64, sections ...types.Section) storage.ScoreRun {
+		return storage.ScoreRun{
+			RequestID:     id,
+			Model:         "gemini-2.0-flash",
+			PromptVersion: prompt,
+			Result:        &types.ScoredResume{OverallScore: overall, Sections: sections},
+		}
+	}
+	job := &storage.Job{RequestID: "job-1", Skills: &types.ExtractedSkills{CompanyInfo: types.CompanyInfo{Name: "Globex", Position: "Engineer"}}}
+	runs := []storage.ScoreRun{
+		run("r1", "", 5, types.Section{Name: "Acme-Engineer", Score: 4}),
+		run("r2", "1", 7, types.Section{Name: " acme-engineer", Score: 6}, types.Section{Name: "Side project", Score: 8}),
+		{RequestID: "failed"},
+		run("r3", "1", 6.5, types.Section{Name: "Acme-Engineer", Score: 7}),
+	}
+	h := Build(job, runs)
+
+	if h.Company != "Globex" || len(h.Runs) != 3 {
+		t.Fatalf("history = %+v", h)
+	}
+	if h.Runs[0].Change != nil || *h.Runs[1].Change != 2 || *h.Runs[2].Change != -0.5 {
+		t.Errorf("changes = %v, %v, %v", h.Runs[0].Change, h.Runs[1].Change, h.Runs[2].Change)
+	}
+	if !h.Runs[1].PromptChanged || h.Runs[2].PromptChanged || h.Runs[2].ModelChanged {
+		t.Errorf("prompt changed = %v, %v", h.Runs[1].PromptChanged, h.Runs[2].PromptChanged)
+	}
+	want := types.ScoreTrend{Runs: 3, First: 5, Last: 6.5, Change: 1.5, Min: 5, Max: 7}
+	if h.Overall != want {
+		t.Errorf("overall = %+v, want %+v", h.Overall, want)
+	}
+
+	if len(h.Sections) != 2 {
+		t.Fatalf("sections = %+v, want Acme-Engineer matched across runs and Side project", h.Sections)
+	}
+	acme := h.Sections[0]
+	if acme.Name != "Acme-Engineer" || acme.Runs != 3 || acme.Change != 3 || acme.Max != 7 {
+		t.Errorf("Acme-Engineer = %+v", acme.ScoreTrend)
+	}
+	if h.Sections[1].Runs != 1 || h.Sections[1].Scores[0].RequestID != "r2" {
+		t.Errorf("Side project = %+v", h.Sections[1])
+	}
+}
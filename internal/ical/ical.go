@@ -0,0 +1,149 @@
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// lineLimit octets per content line, longer ones are folded (RFC 5545 3.1)
+const lineLimit = 75
+
+const (
+	utcFormat  = "20060102T150405Z"
+	dateFormat = "20060102"
+)
+
+// Calendar is a published VCALENDAR feed.
+type Calendar struct {
+	// ProdID identifies the product that wrote the feed
+	ProdID string
+	// Name is shown by clients that support X-WR-CALNAME
+	Name   string
+	Events []Event
+}
+
+// Event is one VEVENT. Times are written in UTC, or as dates when AllDay is set.
+type Event struct {
+	// UID must stay the same across exports so clients update the event instead of duplicating it
+	UID         string
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	// Alarm, when positive, adds a display alarm that long before Start
+	Alarm time.Duration
+}
+
+// Write encodes the calendar with CRLF line endings. stamp is the DTSTAMP of every
+// event, when the feed was generated.
+func (c *Calendar) Write(w io.Writer, stamp time.Time) error {
+	bw := bufio.NewWriter(w)
+	prop(bw, "BEGIN", "VCALENDAR")
+	prop(bw, "VERSION", "2.0")
+	prop(bw, "PRODID", c.ProdID)
+	prop(bw, "CALSCALE", "GREGORIAN")
+	prop(bw, "METHOD", "PUBLISH")
+	if c.Name != "" {
+		prop(bw, "X-WR-CALNAME", Escape(c.Name))
+	}
+	for _, e := range c.Events {
+		prop(bw, "BEGIN", "VEVENT")
+		prop(bw, "UID", e.UID)
+		prop(bw, "DTSTAMP", stamp.UTC().Format(utcFormat))
+		if e.AllDay {
+			end := e.End
+			if !end.After(e.Start) {
+				// DTEND is exclusive, a one-day event ends the next day
+				end = e.Start.AddDate(0, 0, 1)
+			}
+			prop(bw, "DTSTART;VALUE=DATE", e.Start.Format(dateFormat))
+			prop(bw, "DTEND;VALUE=DATE", end.Format(dateFormat))
+		} else {
+			prop(bw, "DTSTART", e.Start.UTC().Format(utcFormat))
+			if e.End.After(e.Start) {
+				prop(bw, "DTEND", e.End.UTC().Format(utcFormat))
+			}
+		}
+		prop(bw, "SUMMARY", Escape(e.Summary))
+		if e.Description != "" {
+			prop(bw, "DESCRIPTION", Escape(e.Description))
+		}
+		if e.Location != "" {
+			prop(bw, "LOCATION", Escape(e.Location))
+		}
+		if e.URL != "" {
+			prop(bw, "URL", e.URL)
+		}
+		if e.Alarm > 0 {
+			prop(bw, "BEGIN", "VALARM")
+			prop(bw, "ACTION", "DISPLAY")
+			prop(bw, "DESCRIPTION", Escape(e.Summary))
+			prop(bw, "TRIGGER", "-"+Duration(e.Alarm))
+			prop(bw, "END", "VALARM")
+		}
+		prop(bw, "END", "VEVENT")
+	}
+	prop(bw, "END", "VCALENDAR")
+	return bw.Flush()
+}
+
+// prop writes one content line, folded at lineLimit octets without splitting a UTF-8
+// sequence. Continuation lines start with a space that counts towards the limit.
+func prop(w *bufio.Writer, name, value string) {
+	line := name + ":" + value
+	limit := lineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		w.WriteString(line[:cut])
+		w.WriteString("\r\n ")
+		line = line[cut:]
+		limit = lineLimit - 1
+	}
+	w.WriteString(line)
+	w.WriteString("\r\n")
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// Escape makes s a TEXT value (RFC 5545 3.3.11).
+func Escape(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// Duration formats d as a DURATION value (RFC 5545 3.3.6) rounded to the minute,
+// e.g. PT1H30M or P1D.
+func Duration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	if d < 0 {
+		d = -d
+	}
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	hours, minutes := d/time.Hour, (d%time.Hour)/time.Minute
+
+	var b strings.Builder
+	b.WriteString("P")
+	if days > 0 {
+		fmt.Fprintf(&b, "%dD", days)
+	}
+	if hours > 0 || minutes > 0 || days == 0 {
+		b.WriteString("T")
+		if hours > 0 {
+			fmt.Fprintf(&b, "%dH", hours)
+		}
+		if minutes > 0 || hours == 0 {
+			fmt.Fprintf(&b, "%dM", minutes)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,136 @@
+package ical
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestEscape(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"plain", "plain"},
+		{"a, b; c", `a\, b\; c`},
+		{`C:\path`, `C:\\path`},
+		{"line one\nline two\r\nline three", `line one\nline two\nline three`},
+	}
+	for _, tt := range tests {
+		if got := Escape(tt.in); got != tt.want {
+			t.Errorf("Escape(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "PT0M"},
+		{15 * time.Minute, "PT15M"},
+		{90 * time.Minute, "PT1H30M"},
+		{2 * time.Hour, "PT2H"},
+		{24 * time.Hour, "P1D"},
+		{26*time.Hour + 30*time.Second, "P1DT2H1M"},
+		{-time.Hour, "PT1H"},
+	}
+	for _, tt := range tests {
+		if got := Duration(tt.d); got != tt.want {
+			t.Errorf("Duration(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestFolding(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{"short", "Phone screen"},
+		{"exactly the limit", strings.Repeat("x", lineLimit-len("SUMMARY:"))},
+		{"ascii", strings.Repeat("System design interview with the platform team ", 5)},
+		{"multibyte", strings.Repeat("Entretien technique à Zürich — équipe paiements ", 5)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			bw := bufio.NewWriter(&buf)
+			prop(bw, "SUMMARY", tt.value)
+			bw.Flush()
+
+			out := buf.String()
+			if !strings.HasSuffix(out, "\r\n") {
+				t.Fatalf("line isn't CRLF terminated: %q", out)
+			}
+			lines := strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n")
+			var unfolded strings.Builder
+			for i, line := range lines {
+				if len(line) > lineLimit {
+					t.Errorf("line %d is %d octets", i, len(line))
+				}
+				if !utf8.ValidString(line) {
+					t.Errorf("line %d splits a UTF-8 sequence: %q", i, line)
+				}
+				if i > 0 {
+					if !strings.HasPrefix(line, " ") {
+						t.Fatalf("continuation line %d doesn't start with a space", i)
+					}
+					line = line[1:]
+				}
+				unfolded.WriteString(line)
+			}
+			if unfolded.String() != "SUMMARY:"+tt.value {
+				t.Errorf("unfolded = %q", unfolded.String())
+			}
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	stamp := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	berlin := time.FixedZone("CET", 3600)
+	cal := Calendar{
+		ProdID: "-//tracker//test//EN",
+		Name:   "Jobs, mostly",
+		Events: []Event{
+			{
+				UID:      "interview-1@tracker",
+				Start:    time.Date(2026, 3, 2, 10, 0, 0, 0, berlin),
+				End:      time.Date(2026, 3, 2, 11, 0, 0, 0, berlin),
+				Summary:  "Globex: Onsite",
+				Location: "Main St 1, Springfield",
+				Alarm:    24 * time.Hour,
+			},
+			{
+				UID:     "deadline-1@tracker",
+				Start:   time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+				AllDay:  true,
+				Summary: "Apply by: Engineer at Acme",
+			},
+		},
+	}
+	var buf bytes.Buffer
+	if err := cal.Write(&buf, stamp); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+		"X-WR-CALNAME:Jobs\\, mostly\r\n",
+		"DTSTAMP:20260301T120000Z\r\n",
+		"DTSTART:20260302T090000Z\r\nDTEND:20260302T100000Z\r\n",
+		"LOCATION:Main St 1\\, Springfield\r\n",
+		"TRIGGER:-P1D\r\n",
+		"DTSTART;VALUE=DATE:20260331\r\nDTEND;VALUE=DATE:20260401\r\n",
+		"END:VEVENT\r\nEND:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("feed is missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "BEGIN:VEVENT") != 2 || strings.Count(out, "BEGIN:VALARM") != 1 {
+		t.Errorf("feed has the wrong events:\n%s", out)
+	}
+}
@@ -0,0 +1,236 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+const (
+	RuleWeakOpener   = "weak_opener"
+	RuleNoMetric     = "no_metric"
+	RulePassiveVoice = "passive_voice"
+	RuleTense        = "tense"
+	RuleFirstPerson  = "first_person"
+	RuleTooLong      = "too_long"
+	RuleRepeatedVerb = "repeated_verb"
+)
+
+const (
+	maxBulletChars = 200
+	maxBulletWords = 35
+)
+
+var weakOpeners = []string{
+	"responsible for", "helped", "help", "helping", "assisted", "assist", "assisting",
+	"worked on", "working on", "involved in", "participated in", "tasked with",
+	"duties included", "in charge of", "contributed to", "was part of", "part of",
+}
+
+var (
+	bulletMarker = regexp.MustCompile(`^\s*([-*•·▪‣◦]|\d+[.)])\s*`)
+	metric       = regexp.MustCompile(`(?i)\d|%|\b(one|two|three|four|five|six|seven|eight|nine|ten|dozens?|hundreds?|thousands?|millions?|billions?|double[ds]?|tripled?|halved?)\b`)
+	passive      = regexp.MustCompile(`(?i)\b(was|were|is|are|been|being|be|got)\s+(\w+ly\s+)?(\w+ed|(re)?(built|made|written|done|run|set|sent)|led|given|taken|driven|shown|chosen|known|kept|sold|taught|brought|held|spent)\b`)
+	firstPerson  = regexp.MustCompile(`\b(I|[Mm]e|[Mm]y|[Mm]ine|[Mm]yself|[Ww]e|[Uu]s|[Oo]ur|[Oo]urs)\b`) // not "US"
+)
+
+// irregularPast covers the common opener verbs that don't end in -ed.
+var irregularPast = map[string]bool{
+	"built": true, "led": true, "ran": true, "wrote": true, "made": true, "drove": true, "grew": true,
+	"won": true, "took": true, "gave": true, "began": true, "cut": true, "brought": true, "taught": true,
+	"sold": true, "bought": true, "found": true, "held": true, "kept": true, "spent": true, "sent": true,
+	"met": true, "rebuilt": true, "oversaw": true, "saw": true, "became": true,
+	"rewrote": true, "overcame": true, "chose": true, "set": true, "spun": true, "split": true,
+}
+
+// presentVerbs are base forms common at the start of a bullet for a current role.
+var presentVerbs = map[string]bool{
+	"build": true, "lead": true, "run": true, "write": true, "make": true, "drive": true, "grow": true,
+	"own": true, "ship": true, "design": true, "develop": true, "manage": true, "create": true,
+	"maintain": true, "implement": true, "improve": true, "deliver": true, "architect": true,
+	"optimize": true, "automate": true, "mentor": true, "launch": true, "migrate": true, "scale": true,
+	"reduce": true, "increase": true, "support": true, "collaborate": true, "partner": true,
+	"coordinate": true, "analyze": true, "define": true, "establish": true, "oversee": true,
+	"deploy": true, "integrate": true, "test": true, "debug": true, "refactor": true, "monitor": true,
+	"configure": true, "document": true, "research": true, "plan": true, "present": true, "train": true,
+	"teach": true, "sell": true, "negotiate": true, "review": true, "operate": true, "handle": true,
+}
+
+type tense int
+
+const (
+	tenseUnknown tense = iota
+	tensePast
+	tensePresent
+)
+
+func (t tense) String() string {
+	if t == tensePast {
+		return "past"
+	}
+	return "present"
+}
+
+// Lint checks the bullets of one role. Each bullet is checked on its own, then tense and
+// opener verbs are compared across the role.
+func Lint(bullets []string) types.LintResponse {
+	resp := types.LintResponse{Bullets: make([]types.BulletLint, len(bullets))}
+	verbs := make([]string, len(bullets))
+	tenses := make([]tense, len(bullets))
+
+	for i, bullet := range bullets {
+		text := Strip(bullet)
+		resp.Bullets[i] = types.BulletLint{Bullet: bullet, Issues: Bullet(text)}
+		verbs[i] = opener(text)
+		tenses[i] = tenseOf(verbs[i])
+	}
+
+	// the role's tense is whatever most bullets use, the first bullet breaks ties
+	var past, present int
+	first := tenseUnknown
+	for _, t := range tenses {
+		switch t {
+		case tensePast:
+			past++
+		case tensePresent:
+			present++
+		}
+		if first == tenseUnknown {
+			first = t
+		}
+	}
+	majority := first
+	if past > present {
+		majority = tensePast
+	} else if present > past {
+		majority = tensePresent
+	}
+
+	seen := map[string]int{}
+	for i := range bullets {
+		if tenses[i] != tenseUnknown && tenses[i] != majority {
+			resp.Bullets[i].Issues = append(resp.Bullets[i].Issues, types.LintIssue{
+				Rule:    RuleTense,
+				Message: fmt.Sprintf("%q is %s tense, the rest of the role is %s", verbs[i], tenses[i], majority),
+			})
+		}
+		if verbs[i] == "" {
+			continue
+		}
+		if j, dup := seen[verbs[i]]; dup {
+			resp.Bullets[i].Issues = append(resp.Bullets[i].Issues, types.LintIssue{
+				Rule:    RuleRepeatedVerb,
+				Message: fmt.Sprintf("%q already opens bullet %d", verbs[i], j+1),
+			})
+			continue
+		}
+		seen[verbs[i]] = i
+	}
+
+	for _, b := range resp.Bullets {
+		resp.Issues += len(b.Issues)
+	}
+	return resp
+}
+
+// Bullet runs the checks that only need the bullet itself.
+func Bullet(text string) []types.LintIssue {
+	issues := []types.LintIssue{}
+	lower := strings.ToLower(text)
+
+	for _, weak := range weakOpeners {
+		if lower == weak || strings.HasPrefix(lower, weak+" ") {
+			issues = append(issues, types.LintIssue{
+				Rule:    RuleWeakOpener,
+				Message: fmt.Sprintf("starts with %q, lead with what you did instead", text[:len(weak)]),
+			})
+			break
+		}
+	}
+	if !metric.MatchString(text) {
+		issues = append(issues, types.LintIssue{
+			Rule:    RuleNoMetric,
+			Message: "no number, percentage or scale shows the impact",
+		})
+	}
+	if m := passive.FindString(text); m != "" {
+		issues = append(issues, types.LintIssue{
+			Rule:    RulePassiveVoice,
+			Message: fmt.Sprintf("%q is passive, say who did it", m),
+		})
+	}
+	if m := pronoun(text); m != "" {
+		issues = append(issues, types.LintIssue{
+			Rule:    RuleFirstPerson,
+			Message: fmt.Sprintf("drop the pronoun %q, bullets are implicitly about you", m),
+		})
+	}
+	if n, words := utf8.RuneCountInString(text), len(strings.Fields(text)); n > maxBulletChars || words > maxBulletWords {
+		issues = append(issues, types.LintIssue{
+			Rule:    RuleTooLong,
+			Message: fmt.Sprintf("%d characters and %d words, keep it under %d characters", n, words, maxBulletChars),
+		})
+	}
+	return issues
+}
+
+// pronoun is the first first-person pronoun in text. An I followed by a slash is
+// part of a term like I/O.
+func pronoun(text string) string {
+	for _, loc := range firstPerson.FindAllStringIndex(text, -1) {
+		m := text[loc[0]:loc[1]]
+		if m == "I" && strings.HasPrefix(text[loc[1]:], "/") {
+			continue
+		}
+		return m
+	}
+	return ""
+}
+
+// Strip removes the list marker a bullet was pasted with.
+func Strip(bullet string) string {
+	return strings.TrimSpace(bulletMarker.ReplaceAllString(bullet, ""))
+}
+
+// Annotate lints the original and the rewritten bullets of a section as two roles,
+// so the before and after can be compared bullet by bullet.
+func Annotate(items []types.TransformedItem) {
+	originals := make([]string, len(items))
+	rewrites := make([]string, len(items))
+	for i, item := range items {
+		originals[i] = item.OriginalBullet
+		rewrites[i] = item.TransformedBullet
+	}
+	before, after := Lint(originals), Lint(rewrites)
+	for i := range items {
+		items[i].OriginalLint = before.Bullets[i].Issues
+		items[i].NewLint = after.Bullets[i].Issues
+	}
+}
+
+func opener(text string) string {
+	fields := strings.Fields(strings.ToLower(text))
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.Trim(fields[0], ".,;:!?\"'()")
+}
+
+func tenseOf(verb string) tense {
+	switch {
+	case verb == "":
+		return tenseUnknown
+	case irregularPast[verb] || (strings.HasSuffix(verb, "ed") && len(verb) > 3):
+		return tensePast
+	case presentVerbs[verb] || strings.HasSuffix(verb, "ing"):
+		return tensePresent
+	case strings.HasSuffix(verb, "s") && presentVerbs[strings.TrimSuffix(verb, "s")]:
+		return tensePresent
+	case strings.HasSuffix(verb, "es") && presentVerbs[strings.TrimSuffix(verb, "es")]:
+		return tensePresent
+	}
+	return tenseUnknown
+}
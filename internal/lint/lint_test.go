@@ -0,0 +1,130 @@
+package lint
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+func rules(issues []types.LintIssue) []string {
+	var got []string
+	for _, issue := range issues {
+		got = append(got, issue.Rule)
+	}
+	return got
+}
+
+func TestBullet(t *testing.T) {
+	tests := []struct {
+		name   string
+		bullet string
+		want   []string
+	}{
+		{"clean", "Cut p99 latency by 40% by moving the cache in front of Postgres", nil},
+		{"weak opener", "Responsible for the billing service serving 2M users", []string{RuleWeakOpener}},
+		{"weak opener needs a word boundary", "Helpdesk tooling rebuilt for 30 agents", nil},
+		{"no metric", "Built the billing service", []string{RuleNoMetric}},
+		{"spelled out scale", "Halved deploy time for the platform team", nil},
+		{"passive", "Billing was rebuilt in Go, saving 20 hours a week", []string{RulePassiveVoice}},
+		{"passive with adverb", "Jobs were quickly migrated to 3 regions", []string{RulePassiveVoice}},
+		{"first person", "Built our billing service for 2M users", []string{RuleFirstPerson}},
+		{"US is no pronoun", "Launched checkout in the US for 2M users", nil},
+		{"I/O is no pronoun", "Cut I/O latency 40% by batching disk writes", nil},
+		{"I after I/O", "Cut I/O latency 40% after I profiled the disk writes", []string{RuleFirstPerson}},
+		{
+			"too long",
+			"Built a billing service in Go that handles invoices, refunds, disputes, and payouts for every merchant on the platform, " +
+				"with retries, idempotency keys, reconciliation jobs and dashboards for the finance team across 12 countries",
+			[]string{RuleTooLong},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rules(Bullet(tt.bullet)); !slices.Equal(got, tt.want) {
+				t.Errorf("Bullet(%q) = %v, want %v", tt.bullet, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStrip(t *testing.T) {
+	for _, bullet := range []string{"- Built it", "  * Built it", "• Built it", "1. Built it", "12) Built it", "Built it"} {
+		if got := Strip(bullet); got != "Built it" {
+			t.Errorf("Strip(%q) = %q", bullet, got)
+		}
+	}
+}
+
+func TestTenseOf(t *testing.T) {
+	tests := []struct {
+		verb string
+		want tense
+	}{
+		{"built", tensePast},
+		{"shipped", tensePast},
+		{"led", tensePast},
+		{"build", tensePresent},
+		{"builds", tensePresent},
+		{"manages", tensePresent},
+		{"leading", tensePresent},
+		{"red", tenseUnknown},
+		{"python", tenseUnknown},
+		{"", tenseUnknown},
+	}
+	for _, tt := range tests {
+		if got := tenseOf(tt.verb); got != tt.want {
+			t.Errorf("tenseOf(%q) = %v, want %v", tt.verb, got, tt.want)
+		}
+	}
+}
+
+func TestLint(t *testing.T) {
+	resp := Lint([]string{
+		"- Built the billing service for 2M users",
+		"- Build dashboards used by 40 analysts",
+		"- Shipped 3 releases a week",
+		"- Built the alerting for 12 services",
+	})
+	want := [][]string{
+		nil,
+		{RuleTense},
+		nil,
+		{RuleRepeatedVerb},
+	}
+	for i, w := range want {
+		if got := rules(resp.Bullets[i].Issues); !slices.Equal(got, w) {
+			t.Errorf("bullet %d issues = %v, want %v", i, got, w)
+		}
+	}
+	if resp.Issues != 2 {
+		t.Errorf("Issues = %d, want 2", resp.Issues)
+	}
+	if resp.Bullets[0].Bullet != "- Built the billing service for 2M users" {
+		t.Errorf("Bullet = %q, want the bullet as sent", resp.Bullets[0].Bullet)
+	}
+}
+
+func TestLintTenseTie(t *testing.T) {
+	// one past and one present bullet, the first one sets the role's tense
+	resp := Lint([]string{"Own the billing service for 2M users", "Built alerting for 12 services"})
+	if got := rules(resp.Bullets[1].Issues); !slices.Equal(got, []string{RuleTense}) {
+		t.Errorf("second bullet issues = %v, want tense", got)
+	}
+	if len(resp.Bullets[0].Issues) != 0 {
+		t.Errorf("first bullet issues = %v, want none", rules(resp.Bullets[0].Issues))
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	items := []types.TransformedItem{
+		{OriginalBullet: "Helped with the billing service", TransformedBullet: "Rebuilt the billing service for 2M users"},
+	}
+	Annotate(items)
+	if got := rules(items[0].OriginalLint); !slices.Equal(got, []string{RuleWeakOpener, RuleNoMetric}) {
+		t.Errorf("OriginalLint = %v", got)
+	}
+	if len(items[0].NewLint) != 0 {
+		t.Errorf("NewLint = %v, want none", rules(items[0].NewLint))
+	}
+}
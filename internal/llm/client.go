@@ -4,18 +4,29 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
 
 	"github.com/google/generative-ai-go/genai"
 	"google.golang.org/api/option"
 
 	"github.com/p-shah256/tracker/internal/cleaner"
+	"github.com/p-shah256/tracker/internal/taxonomy"
+	"github.com/p-shah256/tracker/internal/verify"
 )
 
-var clean = cleaner.NewCleaner()
+var (
+	clean = cleaner.NewCleaner()
+	// job descriptions go to the model as Markdown so it can see headings and list nesting
+	markdownClean = clean.WithMode(cleaner.ModeMarkdown)
+)
 
 type LLM struct {
 	client *genai.Client
 	model  string
+	// allowedModels are the models a request may pick, besides the default
+	allowedModels []string
+	taxonomy      *taxonomy.Taxonomy
+	lengthBand    verify.LengthBand
 }
 
 func New(apiKey string) (*LLM, error) {
@@ -26,19 +37,71 @@ func New(apiKey string) (*LLM, error) {
 	}
 
 	return &LLM{
-		client: client,
-		model:  "gemini-2.0-flash",
+		client:     client,
+		model:      "gemini-2.0-flash",
+		taxonomy:   taxonomy.Default(),
+		lengthBand: verify.DefaultLengthBand,
 	}, nil
 }
 
+// Model is the model used when GenerateOptions doesn't name one.
+func (l *LLM) Model() string {
+	return l.model
+}
+
+// SetAllowedModels sets the models a request may ask for by name. The default model is
+// always allowed.
+func (l *LLM) SetAllowedModels(models []string) {
+	l.allowedModels = models
+}
+
+// AllowedModels lists the models a request may ask for, the default first.
+func (l *LLM) AllowedModels() []string {
+	models := []string{l.model}
+	for _, model := range l.allowedModels {
+		if !slices.Contains(models, model) {
+			models = append(models, model)
+		}
+	}
+	return models
+}
+
+// SetTaxonomy replaces the skill taxonomy extracted and missing skills are normalized with.
+func (l *LLM) SetTaxonomy(t *taxonomy.Taxonomy) {
+	l.taxonomy = t
+}
+
+// SetLengthBand changes how far a transformed bullet's length may move from the original,
+// 0.25 allows ±25%.
+func (l *LLM) SetLengthBand(band verify.LengthBand) {
+	l.lengthBand = band
+}
+
 func (l *LLM) Close() {
 	if l.client != nil {
 		l.client.Close()
 	}
 }
 
+// GenerateOptions overrides model settings for a single call, zero values keep the defaults.
+type GenerateOptions struct {
+	Model       string   `json:"model,omitempty"`
+	Temperature *float32 `json:"temperature,omitempty"`
+}
+
 func (l *LLM) Generate(ctx context.Context, systemPrompt, userPrompt string) (string, error) {
-	model := l.client.GenerativeModel(l.model)
+	return l.GenerateWith(ctx, GenerateOptions{}, systemPrompt, userPrompt)
+}
+
+func (l *LLM) GenerateWith(ctx context.Context, opts GenerateOptions, systemPrompt, userPrompt string) (string, error) {
+	modelName := l.model
+	if opts.Model != "" {
+		modelName = opts.Model
+	}
+	model := l.client.GenerativeModel(modelName)
+	if opts.Temperature != nil {
+		model.SetTemperature(*opts.Temperature)
+	}
 
 	if systemPrompt != "" {
 		model.SystemInstruction = &genai.Content{
@@ -54,6 +117,7 @@ func (l *LLM) Generate(ctx context.Context, systemPrompt, userPrompt string) (st
 	}
 
 	slog.Info("LLM API call completed",
+		"model", modelName,
 		"input_tokens", resp.UsageMetadata.PromptTokenCount,
 		"output_tokens", resp.UsageMetadata.CandidatesTokenCount,
 		"total_tokens", resp.UsageMetadata.TotalTokenCount)
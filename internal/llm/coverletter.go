@@ -0,0 +1,204 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/p-shah256/tracker/internal/verify"
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+const (
+	DefaultCoverLetterTone   = "professional"
+	DefaultCoverLetterLength = "medium"
+	// sections scoring highest against the job are what the letter is built around
+	coverLetterSections = 3
+)
+
+var coverLetterTones = map[string]string{
+	"professional":   "confident and professional, plain language, no cliches",
+	"enthusiastic":   "warm and energetic, showing genuine interest in the company, without gushing",
+	"conversational": "friendly and direct, like a note to a future colleague",
+	"formal":         "formal and reserved, suitable for traditional industries",
+}
+
+// target word counts for the body of the letter
+var coverLetterLengths = map[string]int{
+	"short":  150,
+	"medium": 250,
+	"long":   400,
+}
+
+type CoverLetterOptions struct {
+	Tone   string
+	Length string
+}
+
+// Validate fills in defaults and rejects unknown tones and lengths.
+func (o *CoverLetterOptions) Validate() error {
+	if o.Tone == "" {
+		o.Tone = DefaultCoverLetterTone
+	}
+	if o.Length == "" {
+		o.Length = DefaultCoverLetterLength
+	}
+	if _, ok := coverLetterTones[o.Tone]; !ok {
+		return fmt.Errorf("unknown tone %q, use one of %s", o.Tone, strings.Join(slices.Sorted(maps.Keys(coverLetterTones)), ", "))
+	}
+	if _, ok := coverLetterLengths[o.Length]; !ok {
+		return fmt.Errorf("unknown length %q, use one of short, medium, long", o.Length)
+	}
+	return nil
+}
+
+// GenerateCoverLetter drafts a letter for the job from the resume, leaning on the sections
+// that scored best. The draft is checked against the resume like transformed bullets are,
+// and rewritten once if it makes claims the resume doesn't support.
+func (l *LLM) GenerateCoverLetter(extractedSkills *types.ExtractedSkills, scored *types.ScoredResume, resumeText, jobDesc string, opts CoverLetterOptions) (*types.CoverLetter, error) {
+	logger := slog.With(
+		"component", "llm",
+		"operation", "generate_cover_letter",
+	)
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	// the letter is about experience, a well scored skills list or summary has nothing to tell
+	top := slices.DeleteFunc(slices.Clone(scored.Sections), func(s types.Section) bool {
+		return s.Kind != "" && s.Kind != "experience" && s.Kind != "project"
+	})
+	slices.SortStableFunc(top, func(a, b types.Section) int {
+		switch {
+		case a.Score > b.Score:
+			return -1
+		case a.Score < b.Score:
+			return 1
+		}
+		return 0
+	})
+	top = top[:min(coverLetterSections, len(top))]
+
+	var highlights strings.Builder
+	for _, section := range top {
+		fmt.Fprintf(&highlights, "- %s (score %.1f): %s\n", section.Name, section.Score, section.OriginalContent)
+	}
+	var skillNames []string
+	for _, s := range extractedSkills.RequiredSkills {
+		skillNames = append(skillNames, s.Name)
+	}
+	company := extractedSkills.CompanyInfo
+
+	prompt := fmt.Sprintf(`Write a cover letter for this candidate applying to %s for the %s position.
+
+	Rules:
+	1. Tone: %s
+	2. The body (paragraphs only) should be about %d words
+	3. Build the letter around the strongest matching experience below, and connect it to the job's required skills
+	4. Only state facts found in the resume: never invent numbers, employers, tools, degrees or achievements
+	5. If a required skill is not in the resume, do not claim it
+	6. Sign with the candidate's name from the resume
+
+	Required skills: %s
+
+	Strongest matching experience:
+	%s
+	Full resume:
+	%s
+
+	Return valid JSON without any formatting:
+	{
+	  "greeting": "Dear Hiring Manager,",
+	  "paragraphs": ["opening paragraph", "...", "closing paragraph"],
+	  "closing": "Sincerely,",
+	  "signature": "candidate name"
+	}`, orUnknown(company.Name, "the company"), orUnknown(company.Position, "open"),
+		coverLetterTones[opts.Tone], coverLetterLengths[opts.Length],
+		strings.Join(skillNames, ", "), highlights.String(), resumeText)
+
+	logger.Info("starting cover letter generation", "tone", opts.Tone, "length", opts.Length, "sections", len(top))
+	startTime := time.Now()
+
+	letter, err := l.coverLetter(prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	guard := verify.New(l.taxonomy)
+	jobContext := strings.Join([]string{company.Name, company.Position, jobDesc}, "\n")
+	findings := guard.CheckText(letterText(letter), resumeText, jobContext)
+	if len(findings) > 0 {
+		logger.Info("cover letter has unsupported claims, rewriting", "findings", len(findings))
+		var issues strings.Builder
+		for _, f := range findings {
+			fmt.Fprintf(&issues, "- %s\n", f.Message)
+		}
+		draft, _ := json.Marshal(letter)
+		retry, err := l.coverLetter(fmt.Sprintf(`This cover letter makes claims the candidate's resume does not support:
+	%s
+	Rewrite it without them, changing nothing else. Resume:
+	%s
+
+	Letter:
+	%s
+
+	Return the same JSON structure.`, issues.String(), resumeText, string(draft)))
+		if err != nil {
+			logger.Warn("cover letter rewrite failed, keeping the flagged draft", "error", err)
+		} else {
+			letter = retry
+			findings = guard.CheckText(letterText(letter), resumeText, jobContext)
+		}
+	}
+
+	letter.Company = company.Name
+	letter.Position = company.Position
+	letter.Tone = opts.Tone
+	letter.Length = opts.Length
+	letter.WordCount = len(strings.Fields(strings.Join(letter.Paragraphs, " ")))
+	letter.Findings = findings
+
+	logger.Info("cover letter generated",
+		"words", letter.WordCount,
+		"findings", len(findings),
+		"duration_ms", time.Since(startTime).Milliseconds())
+	return letter, nil
+}
+
+func (l *LLM) coverLetter(prompt string) (*types.CoverLetter, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	slog.Debug("prompt", "prompt", prompt)
+	content, err := l.Generate(ctx, "You are a career coach who writes concise, specific cover letters grounded in the candidate's real experience.", prompt)
+	if err != nil {
+		return nil, fmt.Errorf("cover letter generation failed: %w", err)
+	}
+
+	var letter types.CoverLetter
+	if err := json.Unmarshal([]byte(clean.CleanLlmResponse(content)), &letter); err != nil {
+		return nil, fmt.Errorf("failed to parse LLM response as JSON: %w", err)
+	}
+	if len(letter.Paragraphs) == 0 {
+		return nil, fmt.Errorf("cover letter has no paragraphs")
+	}
+	return &letter, nil
+}
+
+// letterText is everything in the letter the model wrote.
+func letterText(letter *types.CoverLetter) string {
+	parts := append([]string{letter.Greeting}, letter.Paragraphs...)
+	return strings.Join(append(parts, letter.Closing, letter.Signature), "\n")
+}
+
+func orUnknown(s, fallback string) string {
+	if strings.TrimSpace(s) == "" {
+		return fallback
+	}
+	return s
+}
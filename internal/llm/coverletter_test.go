@@ -0,0 +1,49 @@
+package llm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+func TestCoverLetterOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       CoverLetterOptions
+		wantTone   string
+		wantLength string
+		wantErr    bool
+	}{
+		{"defaults", CoverLetterOptions{}, DefaultCoverLetterTone, DefaultCoverLetterLength, false},
+		{"set", CoverLetterOptions{Tone: "formal", Length: "short"}, "formal", "short", false},
+		{"unknown tone", CoverLetterOptions{Tone: "sarcastic"}, "", "", true},
+		{"unknown length", CoverLetterOptions{Length: "epic"}, "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (tt.opts.Tone != tt.wantTone || tt.opts.Length != tt.wantLength) {
+				t.Errorf("options = %+v, want %s %s", tt.opts, tt.wantTone, tt.wantLength)
+			}
+		})
+	}
+}
+
+func TestLetterText(t *testing.T) {
+	letter := &types.CoverLetter{
+		Greeting:   "Dear Globex team,",
+		Paragraphs: []string{"I build Go services.", "I'd like to build yours."},
+		Closing:    "Best regards,",
+		Signature:  "Ada, ex-Google",
+	}
+	text := letterText(letter)
+	for _, part := range []string{letter.Greeting, letter.Paragraphs[1], letter.Closing, letter.Signature} {
+		if !strings.Contains(text, part) {
+			t.Errorf("letter text %q is missing %q", text, part)
+		}
+	}
+}
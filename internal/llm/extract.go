@@ -5,20 +5,56 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
 	"time"
+	"unicode"
 
+	"github.com/p-shah256/tracker/internal/cleaner"
 	"github.com/p-shah256/tracker/pkg/types"
 )
 
 func (l *LLM) ExtractSkills(jobDescContent string) (*types.ExtractedSkills, error) {
+	return l.ExtractSkillsFromPage(jobDescContent, "")
+}
+
+// ExtractSkillsFromPage is ExtractSkills for a fetched page, pageURL lets the cleaner
+// recognise the job board it came from.
+func (l *LLM) ExtractSkillsFromPage(jobDescContent, pageURL string) (*types.ExtractedSkills, error) {
 	logger := slog.With(
 		"component", "llm",
 		"operation", "extract_skills",
 	)
 	logger.Info("starting skill extraction")
 
-	relevantContent := clean.CleanHTML(jobDescContent)
-	logger.Debug("cleaned HTML content", "original_length", len(jobDescContent), "cleaned_length", len(relevantContent))
+	cleaned := markdownClean.CleanPage(jobDescContent, pageURL)
+	// benefits and legal boilerplate get dropped here, they inflate tokens and read like skills
+	relevantContent := cleaner.Labeled(cleaned.Blocks)
+	if relevantContent == "" {
+		relevantContent = cleaned.Text
+	}
+	logger.Debug("cleaned HTML content", "original_length", len(jobDescContent), "cleaned_length", len(relevantContent), "site", cleaned.Site)
+
+	// when the page tells us who is hiring for what, don't spend tokens asking the model;
+	// JobPosting has no seniority, so that's still asked for
+	posting := cleaned.Posting
+	haveCompanyInfo := posting != nil && posting.Title != "" && posting.HiringOrganization != ""
+	if posting != nil {
+		logger.Info("found JobPosting metadata", "title", posting.Title, "organization", posting.HiringOrganization)
+	}
+
+	companyFormat := `,
+		  "company_info": {
+			"name": "company name",
+			"position": "job title",
+			"level": "seniority level"
+		  }`
+	if haveCompanyInfo {
+		companyFormat = `,
+		  "company_info": {
+			"level": "seniority level"
+		  }`
+	}
 
 	prompt := `Parse this job description and extract EVERY keyword that could help match a candidate. Be aggressive and thorough:
 		1. Technical skills (both stated and implied)
@@ -27,6 +63,9 @@ func (l *LLM) ExtractSkills(jobDescContent string) (*types.ExtractedSkills, erro
 		4. Domain expertise areas
 		5. Industry terminology
 
+		The job description is split into labeled parts ([REQUIREMENTS], [NICE_TO_HAVE], [RESPONSIBILITIES], [COMPANY], [OTHER]).
+		Skills from [NICE_TO_HAVE] go to nice_to_have_skills. Do not take skills from [COMPANY], it describes the employer, not the job.
+
 		Format as JSON:
 		{
 		  "required_skills": [
@@ -34,12 +73,7 @@ func (l *LLM) ExtractSkills(jobDescContent string) (*types.ExtractedSkills, erro
 		  ],
 		  "nice_to_have_skills": [
 			{"name": "skill", "importance": 1-10}
-		  ],
-		  "company_info": {
-			"name": "company name",
-			"position": "job title",
-			"level": "seniority level"
-		  }
+		  ]` + companyFormat + `
 		}` + relevantContent
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -62,6 +96,22 @@ func (l *LLM) ExtractSkills(jobDescContent string) (*types.ExtractedSkills, erro
 		return nil, fmt.Errorf("failed to parse LLM response as JSON: %w", err)
 	}
 
+	l.taxonomy.NormalizeExtracted(&extractedSkills)
+	if posting != nil {
+		// the description is the job text itself, the skills go into every later prompt
+		// and carrying it along would pay for it twice
+		metadata := *posting
+		metadata.Description = ""
+		extractedSkills.JobPosting = &metadata
+	}
+	if haveCompanyInfo {
+		extractedSkills.CompanyInfo.Name = posting.HiringOrganization
+		extractedSkills.CompanyInfo.Position = posting.Title
+		if level := inferLevel(posting.Title); level != "" {
+			extractedSkills.CompanyInfo.Level = level
+		}
+	}
+
 	logger.Info("skill extraction completed",
 		"required_skills_count", len(extractedSkills.RequiredSkills),
 		"nice_to_have_skills_count", len(extractedSkills.NiceToHaveSkills),
@@ -69,3 +119,44 @@ func (l *LLM) ExtractSkills(jobDescContent string) (*types.ExtractedSkills, erro
 
 	return &extractedSkills, nil
 }
+
+var levelKeywords = []struct {
+	keyword string
+	level   string
+}{
+	{"intern", "Intern"},
+	{"principal", "Principal"},
+	{"staff", "Staff"},
+	{"lead", "Lead"},
+	{"senior", "Senior"},
+	{"sr", "Senior"},
+	{"junior", "Junior"},
+	{"jr", "Junior"},
+	{"entry", "Entry Level"},
+	{"associate", "Associate"},
+	{"director", "Director"},
+	{"manager", "Manager"},
+}
+
+// inferLevel guesses seniority from a job title, JobPosting has no field for it.
+func inferLevel(title string) string {
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, lk := range levelKeywords {
+		if slices.Contains(words, lk.keyword) {
+			return lk.level
+		}
+	}
+	for _, word := range words {
+		switch word {
+		case "i", "1":
+			return "Entry Level"
+		case "ii", "2":
+			return "Mid Level"
+		case "iii", "3", "iv", "4":
+			return "Senior"
+		}
+	}
+	return ""
+}
@@ -0,0 +1,126 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/p-shah256/tracker/internal/verify"
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+const (
+	technicalQuestions  = 5
+	behavioralQuestions = 3
+	// one question per gap, most important first
+	maxGapQuestions = 5
+)
+
+// PrepareInterview predicts the questions an interviewer is likely to ask for this job,
+// including ones probing the skills the resume is missing, with STAR outlines built from
+// the candidate's own bullets.
+func (l *LLM) PrepareInterview(extractedSkills *types.ExtractedSkills, scored *types.ScoredResume, resumeText string) (*types.InterviewPrep, error) {
+	logger := slog.With(
+		"component", "llm",
+		"operation", "prepare_interview",
+	)
+
+	gaps := l.gaps(scored)
+	gapNames := make([]string, 0, maxGapQuestions)
+	for _, gap := range gaps[:min(maxGapQuestions, len(gaps))] {
+		gapNames = append(gapNames, gap.Name)
+	}
+	skillsJSON, err := json.Marshal(extractedSkills)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal skills data: %w", err)
+	}
+
+	prompt := fmt.Sprintf(`Prepare this candidate for an interview for the job below.
+
+	Write:
+	1. %d technical questions about the job's required skills
+	2. %d behavioral questions fitting the role and level
+	3. One gap question for each of these skills the resume is missing: %s
+	   (how an interviewer would probe the gap, the answer should be honest and point to the closest real experience)
+
+	For every question give STAR talking points (situation, task, action, result) drawn ONLY from the resume bullets
+	below, and copy the bullets you used word for word into source_bullets. Never invent experience or numbers.
+
+	Job requirements:
+	%s
+
+	Resume:
+	%s
+
+	Return valid JSON without any formatting:
+	{
+	  "questions": [{
+		"category": "technical | behavioral | gap",
+		"question": "the question",
+		"skill": "skill it targets, if any",
+		"why": "why the interviewer asks it (1 sentence)",
+		"talking_points": {
+		  "situation": "...",
+		  "task": "...",
+		  "action": "...",
+		  "result": "...",
+		  "source_bullets": ["exact resume bullet"]
+		}
+	  }]
+	}`, technicalQuestions, behavioralQuestions, orUnknown(strings.Join(gapNames, ", "), "none"), string(skillsJSON), resumeText)
+
+	logger.Info("starting interview prep", "gaps", len(gapNames))
+	startTime := time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 45*time.Second)
+	defer cancel()
+
+	logger.Debug("prompt", "prompt", prompt)
+	content, err := l.Generate(ctx, "You are an experienced technical interviewer and career coach.", prompt)
+	if err != nil {
+		return nil, fmt.Errorf("interview prep failed: %w", err)
+	}
+
+	var prep types.InterviewPrep
+	if err := json.Unmarshal([]byte(clean.CleanLlmResponse(content)), &prep); err != nil {
+		return nil, fmt.Errorf("failed to parse LLM response as JSON: %w", err)
+	}
+	prep.Company = extractedSkills.CompanyInfo.Name
+	prep.Position = extractedSkills.CompanyInfo.Position
+	prep.Gaps = gaps
+
+	// talking points have to come from the resume, drop made up source bullets and flag invented details
+	guard := verify.New(l.taxonomy)
+	resumeKey := bulletKey(resumeText)
+	for _, q := range prep.Questions {
+		tp := q.TalkingPoints
+		if tp == nil {
+			continue
+		}
+		tp.SourceBullets = slices.DeleteFunc(tp.SourceBullets, func(b string) bool {
+			key := bulletKey(strings.TrimLeft(strings.TrimSpace(b), "-•*· "))
+			return key == "" || !strings.Contains(resumeKey, key)
+		})
+		tp.Findings = guard.CheckText(strings.Join([]string{tp.Situation, tp.Task, tp.Action, tp.Result}, "\n"), resumeText, q.Question)
+	}
+
+	logger.Info("interview prep completed",
+		"questions", len(prep.Questions),
+		"duration_ms", time.Since(startTime).Milliseconds())
+	return &prep, nil
+}
+
+// gaps merges the missing skills of every section, most important first.
+func (l *LLM) gaps(scored *types.ScoredResume) []types.ExtractedSkill {
+	var missing []types.ExtractedSkill
+	for _, section := range scored.Sections {
+		missing = append(missing, section.MissingSkills...)
+	}
+	gaps := l.taxonomy.NormalizeSkills(missing)
+	slices.SortStableFunc(gaps, func(a, b types.ExtractedSkill) int { return b.Importance - a.Importance })
+	return gaps
+}
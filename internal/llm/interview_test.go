@@ -0,0 +1,30 @@
+package llm
+
+import (
+	"testing"
+
+	"github.com/p-shah256/tracker/internal/taxonomy"
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+func TestGaps(t *testing.T) {
+	l := &LLM{taxonomy: taxonomy.Default()}
+	scored := &types.ScoredResume{Sections: []types.Section{
+		{Name: "Acme-Engineer", MissingSkills: []types.ExtractedSkill{{Name: "k8s", Importance: 2}, {Name: "Rust", Importance: 1}}},
+		{Name: "Globex-Engineer", MissingSkills: []types.ExtractedSkill{{Name: "Kubernetes", Importance: 4}, {Name: "Kafka", Importance: 3}}},
+	}}
+	got := l.gaps(scored)
+	want := []types.ExtractedSkill{
+		{Name: "Kubernetes", Importance: 4},
+		{Name: "Kafka", Importance: 3},
+		{Name: "Rust", Importance: 1},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("gaps = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i].Name != want[i].Name || got[i].Importance != want[i].Importance {
+			t.Errorf("gaps[%d] = %s %d, want %s %d", i, got[i].Name, got[i].Importance, want[i].Name, want[i].Importance)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/p-shah256/tracker/internal/verify"
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+// TailorProfile scores and rewrites the headline and summary for the job. Headline,
+// summary and skills that are empty are read from the resume by the model; the skills
+// list comes back in Skills.Original for ranking.
+func (l *LLM) TailorProfile(extractedSkills *types.ExtractedSkills, req *types.ProfileRequest) (*types.TailoredProfile, error) {
+	logger := slog.With(
+		"component", "llm",
+		"operation", "tailor_profile",
+	)
+	mode := req.Mode
+	if mode == "" {
+		mode = ModeTruthful
+	}
+	if !ValidTransformMode(mode) {
+		return nil, fmt.Errorf("unknown transform mode %q", req.Mode)
+	}
+
+	skillsJSON, err := json.Marshal(extractedSkills)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal skills data: %w", err)
+	}
+
+	rules := "Only use facts found in the resume, never add experience, numbers, employers or skills the candidate doesn't show."
+	if mode == ModeAggressive {
+		rules = "Lean hard into the job's terminology and required skills."
+	}
+
+	prompt := fmt.Sprintf(`Tailor this resume's headline and professional summary to the job.
+	1. Score the current headline and summary from 0 to 10 on how well they pitch the candidate for this role
+	2. Rewrite each: headline under 12 words, summary 2-4 sentences
+	3. %s
+	4. Score your rewrites the same way
+	If the headline, summary or skills are given below, use them as the originals, otherwise copy them from the resume (empty if it has none).
+
+	Job requirements:
+	%s
+
+	Headline: %s
+	Summary: %s
+	Skills: %s
+
+	Resume:
+	%s
+
+	Return valid JSON without any formatting:
+	{
+	  "headline": {"original": "...", "tailored": "...", "original_score": 5, "new_score": 8, "score_reasoning": "1-2 sentences"},
+	  "summary": {"original": "...", "tailored": "...", "original_score": 5, "new_score": 8, "score_reasoning": "1-2 sentences"},
+	  "skills": {"original": ["skills list exactly as on the resume"]}
+	}`, rules, string(skillsJSON), req.Headline, req.Summary, strings.Join(req.Skills, ", "), req.Resume)
+
+	logger.Info("starting profile tailoring", "mode", mode)
+	startTime := time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	logger.Debug("prompt", "prompt", prompt)
+	content, err := l.Generate(ctx, "You are a resume optimization expert who helps tailor resumes to specific job descriptions.", prompt)
+	if err != nil {
+		return nil, fmt.Errorf("profile tailoring failed: %w", err)
+	}
+
+	var profile types.TailoredProfile
+	if err := json.Unmarshal([]byte(clean.CleanLlmResponse(content)), &profile); err != nil {
+		return nil, fmt.Errorf("failed to parse LLM response as JSON: %w", err)
+	}
+
+	// what the user sent wins over what the model copied
+	if req.Headline != "" {
+		profile.Headline.Original = req.Headline
+	}
+	if req.Summary != "" {
+		profile.Summary.Original = req.Summary
+	}
+	if len(req.Skills) > 0 {
+		profile.Skills.Original = req.Skills
+	}
+
+	guard := verify.New(l.taxonomy)
+	for _, text := range []*types.TailoredText{&profile.Headline, &profile.Summary} {
+		text.Findings = guard.CheckBullet(text.Original, text.Tailored, req.Resume)
+		if mode == ModeTruthful && len(text.Findings) > 0 {
+			text.Rejected = true
+			text.Tailored = text.Original
+			text.NewScore = text.OriginalScore
+		}
+	}
+
+	logger.Info("profile tailoring completed",
+		"headline_rejected", profile.Headline.Rejected,
+		"summary_rejected", profile.Summary.Rejected,
+		"duration_ms", time.Since(startTime).Milliseconds())
+	return &profile, nil
+}
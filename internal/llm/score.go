@@ -10,7 +10,16 @@ import (
 	"github.com/p-shah256/tracker/pkg/types"
 )
 
+// ScorePromptVersion is stored with every score, bump it whenever the scoring prompt
+// changes so score history can tell a prompt change from a resume change. Runs scored
+// before versions were recorded have an empty version.
+const ScorePromptVersion = "1"
+
 func (l *LLM) ScoreResume(extractedSkills *types.ExtractedSkills, resumeText string) (*types.ScoredResume, error) {
+	return l.scoreResume(extractedSkills, resumeText, GenerateOptions{})
+}
+
+func (l *LLM) scoreResume(extractedSkills *types.ExtractedSkills, resumeText string, opts GenerateOptions) (*types.ScoredResume, error) {
 	logger := slog.With(
 		"component", "llm",
 		"operation", "score_resume",
@@ -27,8 +36,8 @@ func (l *LLM) ScoreResume(extractedSkills *types.ExtractedSkills, resumeText str
 	}
 
 	// TODO: maybe later you can remove reasoning for each hightlight and just have a single score reasoning for the entire section
-	// TODO: add other items here, maybe just take everything like technical skills as well
 	prompt := fmt.Sprintf(`Score how each part of this resume matches the job requirements. Be brutally honest about what's missing or weak.
+	Score the headline, summary and skills list too when the resume has them: the headline and summary on how well they pitch the candidate for this role, the skills list on relevance to the job and whether the most important skills come first.
 	Job Requirements:
 	%s
 
@@ -41,7 +50,8 @@ func (l *LLM) ScoreResume(extractedSkills *types.ExtractedSkills, resumeText str
 	  "overall_comments": "overall comments on the resume, existing skills, missing skills, etc. (in 3-4 sentences)",
 	  "sections": [
 		{
-	  	"name": "if experience = 'company-position', else 'project name' (ignore others for now)",
+	  	"name": "if experience = 'company-position', if project = 'project name', otherwise 'Headline', 'Summary' or 'Skills' (ignore education and anything else)",
+		  "kind": "experience | project | headline | summary | skills",
 		  "score": 8,
 		  "score_reasoning": "WHY this scores poorly - be specific about what's missing or weak. Be brutal and honest. Be detailed enough to use this reasoning to optimize the resume. Be detailed enough so that it can be used to optimize the resume.",
 		  "original_content": "original content of the item",
@@ -56,7 +66,7 @@ func (l *LLM) ScoreResume(extractedSkills *types.ExtractedSkills, resumeText str
 	defer cancel()
 
 	startTime := time.Now()
-	content, err := l.Generate(ctx, "You are a resume evaluation assistant. Score how well each resume entry matches the job requirements.", prompt)
+	content, err := l.GenerateWith(ctx, opts, "You are a resume evaluation assistant. Score how well each resume entry matches the job requirements.", prompt)
 	if err != nil {
 		logger.Error("resume scoring failed",
 			"error", err,
@@ -76,6 +86,10 @@ func (l *LLM) ScoreResume(extractedSkills *types.ExtractedSkills, resumeText str
 		return nil, fmt.Errorf("failed to parse LLM response as JSON: %w", err)
 	}
 
+	for i := range scoredResume.Sections {
+		scoredResume.Sections[i].MissingSkills = l.taxonomy.NormalizeSkills(scoredResume.Sections[i].MissingSkills)
+	}
+
 	logger.Debug("parsed LLM response", "scored_resume", scoredResume)
 
 	return &scoredResume, nil
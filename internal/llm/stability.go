@@ -0,0 +1,187 @@
+package llm
+
+import (
+	"cmp"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+const (
+	MaxScoreSamples = 7
+	// samples further apart than this (on the 0-10 scale) mark a score as unstable
+	unstableSpread = 2.0
+)
+
+// ScoreVariants builds one GenerateOptions per sample, cycling through the given
+// temperatures and models.
+func ScoreVariants(samples int, temperatures []float32, models []string) []GenerateOptions {
+	n := max(samples, len(temperatures), len(models), 1)
+	variants := make([]GenerateOptions, n)
+	for i := range variants {
+		if len(temperatures) > 0 {
+			t := temperatures[i%len(temperatures)]
+			variants[i].Temperature = &t
+		}
+		if len(models) > 0 {
+			variants[i].Model = models[i%len(models)]
+		}
+	}
+	return variants
+}
+
+// ScoreResumeSamples runs ScoreResume once per variant in parallel and returns the
+// median result. Text fields come from the sample whose overall score is closest to the
+// median, section and overall scores are the medians across samples.
+func (l *LLM) ScoreResumeSamples(extractedSkills *types.ExtractedSkills, resumeText string, variants []GenerateOptions) (*types.ScoredResume, error) {
+	logger := slog.With(
+		"component", "llm",
+		"operation", "score_resume_samples",
+	)
+	if len(variants) > MaxScoreSamples {
+		return nil, fmt.Errorf("at most %d samples are allowed, got %d", MaxScoreSamples, len(variants))
+	}
+	logger.Info("starting multi-sample scoring", "samples", len(variants))
+	startTime := time.Now()
+
+	results := make([]*types.ScoredResume, len(variants))
+	errs := make([]error, len(variants))
+	var wg sync.WaitGroup
+	for i, opts := range variants {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = l.scoreResume(extractedSkills, resumeText, opts)
+		}()
+	}
+	wg.Wait()
+
+	var samples []*types.ScoredResume
+	for i, result := range results {
+		if errs[i] != nil {
+			logger.Warn("score sample failed", "sample", i, "error", errs[i])
+			continue
+		}
+		samples = append(samples, result)
+	}
+	if len(samples) == 0 {
+		return nil, fmt.Errorf("all %d score samples failed: %w", len(variants), errs[0])
+	}
+
+	aggregated := aggregateScores(samples)
+	aggregated.Stability.Failed = len(variants) - len(samples)
+
+	logger.Info("multi-sample scoring completed",
+		"samples", len(samples),
+		"failed", aggregated.Stability.Failed,
+		"overall_median", aggregated.Stability.Overall.Median,
+		"overall_spread", aggregated.Stability.Overall.Spread,
+		"duration_ms", time.Since(startTime).Milliseconds())
+
+	return aggregated, nil
+}
+
+func aggregateScores(samples []*types.ScoredResume) *types.ScoredResume {
+	overall := make([]float64, len(samples))
+	for i, sample := range samples {
+		overall[i] = sample.OverallScore
+	}
+	overallSpread := spread(overall)
+
+	// the sample nearest the median supplies the reasoning text
+	representative := samples[0]
+	for _, sample := range samples[1:] {
+		if math.Abs(sample.OverallScore-overallSpread.Median) < math.Abs(representative.OverallScore-overallSpread.Median) {
+			representative = sample
+		}
+	}
+
+	// samples don't always return the same sections, keep the ones most of them agree exist
+	sectionScores := map[string][]float64{}
+	for _, sample := range samples {
+		for _, section := range sample.Sections {
+			k := SectionKey(section.Name)
+			sectionScores[k] = append(sectionScores[k], section.Score)
+		}
+	}
+
+	result := *representative
+	result.OverallScore = overallSpread.Median
+	result.Sections = nil
+	stability := &types.ScoreStability{
+		Samples: len(samples),
+		Overall: overallSpread,
+	}
+
+	for _, section := range representative.Sections {
+		scores := sectionScores[SectionKey(section.Name)]
+		if len(scores)*2 < len(samples) {
+			continue
+		}
+		s := spread(scores)
+		section.Score = s.Median
+		result.Sections = append(result.Sections, section)
+		stability.Sections = append(stability.Sections, types.SectionSpread{Name: section.Name, ScoreSpread: s})
+	}
+	result.Stability = stability
+	return &result
+}
+
+func spread(scores []float64) types.ScoreSpread {
+	sorted := slices.Clone(scores)
+	slices.Sort(sorted)
+	n := len(sorted)
+
+	median := sorted[n/2]
+	if n%2 == 0 {
+		median = (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+
+	var mean, variance float64
+	for _, s := range sorted {
+		mean += s
+	}
+	mean /= float64(n)
+	for _, s := range sorted {
+		variance += (s - mean) * (s - mean)
+	}
+	variance /= float64(n)
+
+	s := types.ScoreSpread{
+		Median: median,
+		Min:    sorted[0],
+		Max:    sorted[n-1],
+		Spread: sorted[n-1] - sorted[0],
+		StdDev: math.Round(math.Sqrt(variance)*100) / 100,
+		Scores: scores,
+	}
+	s.Unstable = s.Spread >= unstableSpread
+	return s
+}
+
+// SectionKey is what sections are matched on across score runs, their name without case
+// or extra whitespace.
+func SectionKey(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
+
+// ScoreModel names the model(s) a set of variants scores with, for the score history.
+func (l *LLM) ScoreModel(variants []GenerateOptions) string {
+	var models []string
+	for _, variant := range variants {
+		model := cmp.Or(variant.Model, l.model)
+		if !slices.Contains(models, model) {
+			models = append(models, model)
+		}
+	}
+	if len(models) == 0 {
+		return l.model
+	}
+	return strings.Join(models, ",")
+}
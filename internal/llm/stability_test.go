@@ -0,0 +1,100 @@
+package llm
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+func TestScoreVariants(t *testing.T) {
+	tests := []struct {
+		name         string
+		samples      int
+		temperatures []float32
+		models       []string
+		wantLen      int
+	}{
+		{"defaults", 0, nil, nil, 1},
+		{"samples only", 3, nil, nil, 3},
+		{"temperatures set the count", 1, []float32{0, 0.5}, nil, 2},
+		{"cycled", 4, []float32{0.2}, []string{"a", "b"}, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			variants := ScoreVariants(tt.samples, tt.temperatures, tt.models)
+			if len(variants) != tt.wantLen {
+				t.Fatalf("got %d variants, want %d", len(variants), tt.wantLen)
+			}
+			for i, v := range variants {
+				if len(tt.models) > 0 && v.Model != tt.models[i%len(tt.models)] {
+					t.Errorf("variant %d model = %q", i, v.Model)
+				}
+				if len(tt.temperatures) > 0 && (v.Temperature == nil || *v.Temperature != tt.temperatures[i%len(tt.temperatures)]) {
+					t.Errorf("variant %d temperature = %v", i, v.Temperature)
+				}
+				if len(tt.temperatures) == 0 && v.Temperature != nil {
+					t.Errorf("variant %d has a temperature nobody asked for", i)
+				}
+			}
+		})
+	}
+}
+
+func TestSpread(t *testing.T) {
+	tests := []struct {
+		scores   []float64
+		median   float64
+		spread   float64
+		unstable bool
+	}{
+		{[]float64{7}, 7, 0, false},
+		{[]float64{6, 8}, 7, 2, true},
+		{[]float64{7, 5, 6}, 6, 2, true},
+		{[]float64{7, 7.5, 7, 8}, 7.25, 1, false},
+	}
+	for _, tt := range tests {
+		s := spread(tt.scores)
+		if s.Median != tt.median || s.Spread != tt.spread || s.Unstable != tt.unstable {
+			t.Errorf("spread(%v) = median %v spread %v unstable %v, want %v %v %v",
+				tt.scores, s.Median, s.Spread, s.Unstable, tt.median, tt.spread, tt.unstable)
+		}
+		if !slices.Equal(s.Scores, tt.scores) {
+			t.Errorf("spread(%v) reordered the scores to %v", tt.scores, s.Scores)
+		}
+	}
+}
+
+func TestAggregateScores(t *testing.T) {
+	sample := func(overall float64, comment string, sections ...types.Section) *types.ScoredResume {
+		return &types.ScoredResume{OverallScore: overall, OverallComments: comment, Sections: sections}
+	}
+	samples := []*types.ScoredResume{
+		sample(6, "low", types.Section{Name: "Acme-Engineer", Score: 5}, types.Section{Name: "Side project", Score: 9}),
+		sample(7, "middle", types.Section{Name: "acme-engineer ", Score: 7}),
+		sample(9, "high", types.Section{Name: "Acme-Engineer", Score: 8}),
+	}
+	got := aggregateScores(samples)
+
+	if got.OverallScore != 7 || got.OverallComments != "middle" {
+		t.Errorf("overall = %v %q, want the median 7 with its sample's comments", got.OverallScore, got.OverallComments)
+	}
+	// the side project only came back in one sample of three
+	if len(got.Sections) != 1 || got.Sections[0].Score != 7 {
+		t.Errorf("sections = %+v, want Acme-Engineer at its median 7", got.Sections)
+	}
+	if got.Stability == nil || got.Stability.Samples != 3 || !got.Stability.Overall.Unstable {
+		t.Errorf("stability = %+v", got.Stability)
+	}
+}
+
+func TestAllowedModels(t *testing.T) {
+	l := &LLM{model: "default-model"}
+	if got := l.AllowedModels(); !slices.Equal(got, []string{"default-model"}) {
+		t.Errorf("AllowedModels() = %v, want only the default", got)
+	}
+	l.SetAllowedModels([]string{"cheap-model", "default-model"})
+	if got := l.AllowedModels(); !slices.Equal(got, []string{"default-model", "cheap-model"}) {
+		t.Errorf("AllowedModels() = %v", got)
+	}
+}
@@ -5,28 +5,70 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"math"
+	"strings"
 	"time"
 
+	"github.com/p-shah256/tracker/internal/verify"
 	"github.com/p-shah256/tracker/pkg/types"
 )
 
-func (l *LLM) TransformResumeBullets(scored *types.Section) (types.TransformResponse, error) {
+const (
+	// ModeTruthful only rephrases what the resume already says, rewrites that add
+	// numbers, employers, tools or claims are rejected.
+	ModeTruthful = "truthful"
+	// ModeAggressive is the old behaviour: bullets are bent towards the job and any
+	// unsupported additions are only flagged.
+	ModeAggressive = "aggressive"
+)
+
+var transformRules = map[string]string{
+	ModeTruthful: `Rephrase these resume bullets to better match the job requirements, using only facts the candidate already states:
+		1. Reword existing experience using the job's terminology where it genuinely applies
+		2. Only mention a skill from missing_skills if the original bullet or resume already shows that experience
+		3. Never add numbers, percentages, employers, products, tools or achievements that are not in the original bullet or resume
+		4. Use direct, simple language with job-specific terms
+		5. Stay within ±%d%% of original character count
+		6. Start with strong action verbs`,
+	ModeAggressive: `Transform these resume bullets to exactly match the job requirements, regardless of original content:
+		1. Replace original skills with required job skills from the missing_skills list
+		2. Keep metrics (numbers, percentages) but apply them to new context
+		3. Use direct, simple language with job-specific terms
+		4. Stay within ±%d%% of original character count
+		5. Start with strong action verbs`,
+}
+
+func ValidTransformMode(mode string) bool {
+	_, ok := transformRules[mode]
+	return ok || mode == ""
+}
+
+func (l *LLM) TransformResumeBullets(req *types.TransformRequest) (types.TransformResponse, error) {
+	mode := req.Mode
+	if mode == "" {
+		mode = ModeTruthful
+	}
+	rules, ok := transformRules[mode]
+	if !ok {
+		return types.TransformResponse{}, fmt.Errorf("unknown transform mode %q", req.Mode)
+	}
+
 	// only send missing skills and existing skills, and overall comments instead of sending all the extracted skills
 	// section has all the items required
-	sectionStr, err := json.Marshal(*scored)
+	sectionStr, err := json.Marshal(req.Section)
 	if err != nil {
 		return types.TransformResponse{}, fmt.Errorf("failed to marshal section data: %w", err)
 	}
 
-	prompt := fmt.Sprintf(`Transform these resume bullets to exactly match the job requirements, regardless of original content:
-		1. Replace original skills with required job skills from the missing_skills list
-		2. Keep metrics (numbers, percentages) but apply them to new context
-		3. Use direct, simple language with job-specific terms
-		4. Stay within ±25%% of original character count
-		5. Start with strong action verbs
+	resumeContext := ""
+	if req.Resume != "" {
+		resumeContext = fmt.Sprintf("\n\n\t\tFull resume, for reference only:\n\t\t%s", req.Resume)
+	}
+
+	prompt := fmt.Sprintf(rules+`
 
 		Section to transform:
-		%s
+		%s%s
 
 		Return as JSON array:
 		{
@@ -42,8 +84,91 @@ func (l *LLM) TransformResumeBullets(scored *types.Section) (types.TransformResp
 			"new_score": 8,
 			}, ...]
 		"improvement_explanation": "how this rewrite addresses the weaknesses of this section (2-3 sentences)"
-		}`, string(sectionStr))
+		}`, int(math.Round(float64(l.lengthBand)*100)), string(sectionStr), resumeContext)
+
+	transformedItems, err := l.transform(prompt)
+	if err != nil {
+		return types.TransformResponse{}, err
+	}
+
+	l.fitLength(transformedItems.Items)
 
+	// a rewrite may only say what the bullet or the rest of the resume already says
+	resumeText := req.Resume
+	if resumeText == "" {
+		resumeText = req.OriginalContent
+	}
+	transformedItems.Items = verify.New(l.taxonomy).Verify(transformedItems.Items, resumeText, mode == ModeTruthful, l.lengthBand)
+	transformedItems.Mode = mode
+
+	rejected := 0
+	for _, item := range transformedItems.Items {
+		if item.Rejected {
+			rejected++
+		}
+	}
+	if rejected > 0 {
+		slog.Info("rejected unsupported bullet rewrites", "section", req.Name, "rejected", rejected, "items", len(transformedItems.Items))
+	}
+
+	return transformedItems, nil
+}
+
+const maxLengthRetries = 2
+
+// fitLength asks again for the rewrites that are too long or too short, giving each
+// bullet its exact character range. The model is bad at counting, so it often takes a retry.
+func (l *LLM) fitLength(items []types.TransformedItem) {
+	for attempt := 1; attempt <= maxLengthRetries; attempt++ {
+		verify.CountChars(items)
+
+		var retry strings.Builder
+		outOfBand := map[string]int{}
+		for i, item := range items {
+			if l.lengthBand.Contains(item.CharCountOriginal, item.CharCountNew) {
+				continue
+			}
+			lo, hi := l.lengthBand.Bounds(item.CharCountOriginal)
+			outOfBand[bulletKey(item.OriginalBullet)] = i
+			fmt.Fprintf(&retry, "- original: %q\n  your rewrite (%d characters): %q\n  required length: %d-%d characters\n",
+				item.OriginalBullet, item.CharCountNew, item.TransformedBullet, lo, hi)
+		}
+		if len(outOfBand) == 0 {
+			return
+		}
+		slog.Info("retrying bullets outside the length band", "bullets", len(outOfBand), "attempt", attempt)
+
+		prompt := fmt.Sprintf(`These rewritten resume bullets are outside their allowed length. Rewrite each one to fit its character range, keeping its meaning and skills and adding nothing new:
+		%s
+		Return as JSON:
+		{
+		"items": [{
+			"original_bullet": "original text, unchanged",
+			"transformed_bullet": "rewritten text"
+			}, ...]
+		}`, retry.String())
+
+		retried, err := l.transform(prompt)
+		if err != nil {
+			slog.Warn("length retry failed", "error", err, "attempt", attempt)
+			return
+		}
+		for _, r := range retried.Items {
+			i, ok := outOfBand[bulletKey(r.OriginalBullet)]
+			if !ok || strings.TrimSpace(r.TransformedBullet) == "" {
+				continue
+			}
+			items[i].TransformedBullet = r.TransformedBullet
+		}
+	}
+	verify.CountChars(items)
+}
+
+func bulletKey(bullet string) string {
+	return strings.ToLower(strings.Join(strings.Fields(bullet), " "))
+}
+
+func (l *LLM) transform(prompt string) (types.TransformResponse, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
@@ -60,6 +185,5 @@ func (l *LLM) TransformResumeBullets(scored *types.Section) (types.TransformResp
 	if err := json.Unmarshal([]byte(cleanResponse), &transformedItems); err != nil {
 		return types.TransformResponse{}, fmt.Errorf("failed to parse LLM response as JSON: %w", err)
 	}
-
 	return transformedItems, nil
 }
@@ -0,0 +1,222 @@
+package matcher
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/p-shah256/tracker/internal/taxonomy"
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+const (
+	requiredWeight   = 1.0
+	niceToHaveWeight = 0.5
+	// nice-to-haves can lift the score but never carry more than this share of it
+	niceToHaveShare = 0.2
+)
+
+// Matcher is an ATS style keyword matcher: it checks which extracted skills literally
+// appear in the resume, by alias and word stem, and reports where.
+type Matcher struct {
+	taxonomy *taxonomy.Taxonomy
+}
+
+func New(t *taxonomy.Taxonomy) *Matcher {
+	return &Matcher{taxonomy: t}
+}
+
+type token struct {
+	start, end int
+	text       string
+	stem       string
+}
+
+// Coverage is deterministic: the same skills and resume always give the same result.
+func (m *Matcher) Coverage(skills *types.ExtractedSkills, resumeText string) *types.KeywordCoverage {
+	resume := []rune(resumeText)
+	tokens := tokenize(resume)
+
+	coverage := &types.KeywordCoverage{}
+	var reqTotal, reqMatched, niceTotal, niceMatched float64
+
+	add := func(skill types.ExtractedSkill, required bool) {
+		weight := float64(max(skill.Importance, 1)) * niceToHaveWeight
+		if required {
+			weight = float64(max(skill.Importance, 1)) * requiredWeight
+		}
+
+		spans := m.find(skill, resume, tokens)
+		match := types.SkillMatch{
+			Skill:    skill,
+			Required: required,
+			Weight:   weight,
+			Matched:  len(spans) > 0,
+			Spans:    spans,
+		}
+		coverage.Matches = append(coverage.Matches, match)
+
+		if required {
+			reqTotal += weight
+			if match.Matched {
+				reqMatched += weight
+			}
+		} else {
+			niceTotal += weight
+			if match.Matched {
+				niceMatched += weight
+			}
+		}
+	}
+	for _, skill := range skills.RequiredSkills {
+		add(skill, true)
+	}
+	for _, skill := range skills.NiceToHaveSkills {
+		add(skill, false)
+	}
+
+	if reqTotal > 0 {
+		coverage.RequiredCoverage = round(reqMatched/reqTotal, 3)
+	}
+	if niceTotal > 0 {
+		coverage.NiceToHaveCoverage = round(niceMatched/niceTotal, 3)
+	}
+
+	switch {
+	case reqTotal > 0 && niceTotal > 0:
+		coverage.Score = 10 * ((1-niceToHaveShare)*coverage.RequiredCoverage + niceToHaveShare*coverage.NiceToHaveCoverage)
+	case reqTotal > 0:
+		coverage.Score = 10 * coverage.RequiredCoverage
+	case niceTotal > 0:
+		coverage.Score = 10 * coverage.NiceToHaveCoverage
+	}
+	coverage.Score = round(coverage.Score, 1)
+
+	return coverage
+}
+
+// terms lists every spelling that counts for a skill. A more specific skill implies the
+// broader one, so EKS on the resume covers a Kubernetes requirement.
+func (m *Matcher) terms(skill types.ExtractedSkill) []string {
+	terms := []string{skill.Name}
+	id := skill.ID
+	if id == "" {
+		if known, ok := m.taxonomy.Lookup(skill.Name); ok {
+			id = known.ID
+		}
+	}
+	if id == "" {
+		return terms
+	}
+
+	queue := []string{id}
+	seen := map[string]bool{}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if seen[current] {
+			continue
+		}
+		seen[current] = true
+		terms = append(terms, m.taxonomy.Aliases(current)...)
+		queue = append(queue, m.taxonomy.Children(current)...)
+	}
+	return terms
+}
+
+func (m *Matcher) find(skill types.ExtractedSkill, resume []rune, tokens []token) []types.MatchSpan {
+	var spans []types.MatchSpan
+	// token indexes already inside a span, so "AWS EKS" isn't reported again as "EKS"
+	taken := make([]bool, len(tokens))
+
+	type phrase struct {
+		term   string
+		tokens []token
+	}
+	var phrases []phrase
+	for _, term := range m.terms(skill) {
+		if t := tokenize([]rune(term)); len(t) > 0 {
+			phrases = append(phrases, phrase{term: term, tokens: t})
+		}
+	}
+	// longest first so the widest match claims the tokens
+	slices.SortStableFunc(phrases, func(a, b phrase) int { return len(b.tokens) - len(a.tokens) })
+
+	for _, p := range phrases {
+		// "Go", "C", "R": lowercase "go" in a resume is almost always the verb
+		caseSensitive := len(p.tokens) == 1 && len([]rune(p.tokens[0].text)) <= 2
+
+		for i := 0; i+len(p.tokens) <= len(tokens); i++ {
+			if slices.Contains(taken[i:i+len(p.tokens)], true) || !phraseAt(tokens[i:], p.tokens, caseSensitive) {
+				continue
+			}
+			first, last := tokens[i], tokens[i+len(p.tokens)-1]
+			for j := i; j < i+len(p.tokens); j++ {
+				taken[j] = true
+			}
+			spans = append(spans, types.MatchSpan{
+				Start: first.start,
+				End:   last.end,
+				Text:  string(resume[first.start:last.end]),
+				Term:  p.term,
+			})
+		}
+	}
+
+	slices.SortFunc(spans, func(a, b types.MatchSpan) int { return a.Start - b.Start })
+	return spans
+}
+
+func phraseAt(tokens, phrase []token, caseSensitive bool) bool {
+	for j, p := range phrase {
+		t := tokens[j]
+		if caseSensitive {
+			if t.text != p.text && t.text != strings.ToUpper(p.text) {
+				return false
+			}
+			continue
+		}
+		if t.stem != p.stem {
+			return false
+		}
+	}
+	return true
+}
+
+// tokenize splits on anything that isn't a letter, digit, '+' or '#', so "C++", "C#"
+// survive and "Node.js" becomes "node" "js" on both sides of the comparison.
+func tokenize(text []rune) []token {
+	var tokens []token
+	start := -1
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+		word := string(text[start:end])
+		tokens = append(tokens, token{
+			start: start,
+			end:   end,
+			text:  word,
+			stem:  stem(strings.ToLower(word)),
+		})
+		start = -1
+	}
+
+	for i, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '+' || r == '#' {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		flush(i)
+	}
+	flush(len(text))
+	return tokens
+}
+
+func round(v float64, places int) float64 {
+	p := math.Pow(10, float64(places))
+	return math.Round(v*p) / p
+}
@@ -0,0 +1,163 @@
+package matcher
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/p-shah256/tracker/internal/taxonomy"
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+func TestStem(t *testing.T) {
+	tests := []struct {
+		words []string
+		want  string
+	}{
+		{[]string{"deploy", "deployed", "deploying", "deploys"}, "deploy"},
+		{[]string{"manage", "managed", "managing", "manages"}, "manag"},
+		{[]string{"query", "queries", "queried"}, "query"},
+		{[]string{"run", "running"}, "run"},
+		{[]string{"process", "processes"}, "process"},
+		{[]string{"k8s"}, "k8s"},
+		{[]string{"aws"}, "aws"},
+		{[]string{"status"}, "status"},
+	}
+	for _, tt := range tests {
+		for _, word := range tt.words {
+			if got := stem(word); got != tt.want {
+				t.Errorf("stem(%q) = %q, want %q", word, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	tokens := tokenize([]rune("Built C++ & C# services on Node.js (k8s)"))
+	var got []string
+	for _, tok := range tokens {
+		got = append(got, tok.text)
+	}
+	want := "Built C++ C# services on Node js k8s"
+	if strings.Join(got, " ") != want {
+		t.Errorf("tokens = %q, want %q", got, want)
+	}
+	if tokens[1].start != 6 || tokens[1].end != 9 {
+		t.Errorf("C++ span = %d-%d, want 6-9", tokens[1].start, tokens[1].end)
+	}
+}
+
+func TestCoverage(t *testing.T) {
+	m := New(taxonomy.Default())
+	tests := []struct {
+		name     string
+		skills   types.ExtractedSkills
+		resume   string
+		matched  []bool
+		score    float64
+		firstHit string
+	}{
+		{
+			name:     "alias and stem",
+			skills:   types.ExtractedSkills{RequiredSkills: []types.ExtractedSkill{{Name: "Kubernetes", Importance: 1}, {Name: "PostgreSQL", Importance: 1}}},
+			resume:   "Ran k8s clusters and tuned Postgres queries.",
+			matched:  []bool{true, true},
+			score:    10,
+			firstHit: "k8s",
+		},
+		{
+			name:     "child skill covers parent",
+			skills:   types.ExtractedSkills{RequiredSkills: []types.ExtractedSkill{{Name: "Kubernetes", Importance: 1}}},
+			resume:   "Migrated services to AWS EKS.",
+			matched:  []bool{true},
+			score:    10,
+			firstHit: "AWS EKS",
+		},
+		{
+			name:    "short names are case sensitive",
+			skills:  types.ExtractedSkills{RequiredSkills: []types.ExtractedSkill{{Name: "Go", Importance: 1}}},
+			resume:  "Helped the team go faster.",
+			matched: []bool{false},
+			score:   0,
+		},
+		{
+			name: "weighted by importance, nice to haves capped",
+			skills: types.ExtractedSkills{
+				RequiredSkills:   []types.ExtractedSkill{{Name: "Go", Importance: 3}, {Name: "Rust", Importance: 1}},
+				NiceToHaveSkills: []types.ExtractedSkill{{Name: "Terraform", Importance: 2}},
+			},
+			resume:  "Wrote Go services, provisioned with Terraform.",
+			matched: []bool{true, false, true},
+			// 10 * (0.8 * 3/4 + 0.2 * 1)
+			score:    8,
+			firstHit: "Go",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			coverage := m.Coverage(&tt.skills, tt.resume)
+			if len(coverage.Matches) != len(tt.matched) {
+				t.Fatalf("got %d matches, want %d", len(coverage.Matches), len(tt.matched))
+			}
+			for i, want := range tt.matched {
+				if coverage.Matches[i].Matched != want {
+					t.Errorf("%s matched = %v, want %v", coverage.Matches[i].Skill.Name, !want, want)
+				}
+			}
+			if coverage.Score != tt.score {
+				t.Errorf("Score = %v, want %v", coverage.Score, tt.score)
+			}
+			if tt.firstHit != "" {
+				spans := coverage.Matches[0].Spans
+				if len(spans) == 0 || spans[0].Text != tt.firstHit {
+					t.Errorf("first span = %+v, want %q", spans, tt.firstHit)
+				}
+			}
+		})
+	}
+}
+
+func TestCoverageIsDeterministic(t *testing.T) {
+	m := New(taxonomy.Default())
+	skills := &types.ExtractedSkills{RequiredSkills: []types.ExtractedSkill{{Name: "Python", Importance: 2}, {Name: "AWS", Importance: 1}}}
+	resume := "Python pipelines on Amazon Web Services, more python."
+	first := m.Coverage(skills, resume)
+	for range 5 {
+		again := m.Coverage(skills, resume)
+		if again.Score != first.Score || len(again.Matches[0].Spans) != len(first.Matches[0].Spans) {
+			t.Fatal("Coverage gave a different result for the same input")
+		}
+	}
+	if len(first.Matches[0].Spans) != 2 {
+		t.Errorf("Python spans = %+v, want both mentions", first.Matches[0].Spans)
+	}
+}
+
+func TestRankSkills(t *testing.T) {
+	m := New(taxonomy.Default())
+	skills := &types.ExtractedSkills{
+		RequiredSkills:   []types.ExtractedSkill{{Name: "Kubernetes", Importance: 3}, {Name: "PostgreSQL", Importance: 2}, {Name: "Rust", Importance: 1}},
+		NiceToHaveSkills: []types.ExtractedSkill{{Name: "Terraform", Importance: 2}},
+	}
+	got := m.RankSkills(skills, []string{"Pottery", "Terraform", "MySQL", "Postgres", "EKS"})
+
+	var order []string
+	for _, s := range got.Ordered {
+		order = append(order, s.Name)
+	}
+	if want := "EKS,Postgres,Terraform,Pottery,MySQL"; strings.Join(order, ",") != want {
+		t.Errorf("Ordered = %s, want %s", strings.Join(order, ","), want)
+	}
+	if got.Ordered[0].MatchedSkill != "Kubernetes" || !got.Ordered[0].Required || got.Ordered[0].Importance != 3 {
+		t.Errorf("EKS = %+v, want it counted towards Kubernetes", got.Ordered[0])
+	}
+	// MySQL is another database, nothing on the list is about pottery
+	if strings.Join(got.Drop, ",") != "Pottery" {
+		t.Errorf("Drop = %v, want Pottery", got.Drop)
+	}
+	if strings.Join(got.Missing, ",") != "Rust" {
+		t.Errorf("Missing = %v, want Rust", got.Missing)
+	}
+	if strings.Join(got.Original, ",") != "Pottery,Terraform,MySQL,Postgres,EKS" {
+		t.Errorf("Original = %v, want the list as sent", got.Original)
+	}
+}
@@ -0,0 +1,87 @@
+package matcher
+
+import (
+	"slices"
+
+	"github.com/p-shah256/tracker/internal/taxonomy"
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+// RankSkills orders a resume's skills list by what the job asks for: required skills
+// first, then by importance, otherwise keeping the user's order. Skills unrelated to
+// anything in the job are suggested for removal, required skills nowhere on the list
+// are reported as missing.
+func (m *Matcher) RankSkills(skills *types.ExtractedSkills, resumeSkills []string) types.TailoredSkills {
+	type jobSkill struct {
+		skill    types.ExtractedSkill
+		required bool
+	}
+	var job []jobSkill
+	categories := map[string]bool{}
+	for _, s := range skills.RequiredSkills {
+		job = append(job, jobSkill{m.taxonomy.Normalize(s), true})
+	}
+	for _, s := range skills.NiceToHaveSkills {
+		job = append(job, jobSkill{m.taxonomy.Normalize(s), false})
+	}
+	for _, j := range job {
+		if j.skill.Category != "" {
+			categories[j.skill.Category] = true
+		}
+	}
+
+	result := types.TailoredSkills{Original: resumeSkills}
+	covered := map[string]bool{}
+	for _, name := range resumeSkills {
+		own := m.taxonomy.Normalize(types.ExtractedSkill{Name: name})
+		ranked := types.RankedSkill{Name: name}
+		for _, j := range job {
+			if !m.counts(own, j.skill) {
+				continue
+			}
+			covered[taxonomy.SkillKey(j.skill)] = true
+			better := j.required && !ranked.Required ||
+				j.required == ranked.Required && j.skill.Importance > ranked.Importance
+			if better || ranked.MatchedSkill == "" {
+				ranked.Importance = j.skill.Importance
+				ranked.Required = j.required
+				ranked.MatchedSkill = j.skill.Name
+			}
+		}
+		result.Ordered = append(result.Ordered, ranked)
+
+		// a known skill in a category the job doesn't touch (or an unknown one) adds nothing
+		if ranked.MatchedSkill == "" && (own.Category == "" || !categories[own.Category]) {
+			result.Drop = append(result.Drop, name)
+		}
+	}
+
+	slices.SortStableFunc(result.Ordered, func(a, b types.RankedSkill) int {
+		if a.Required != b.Required {
+			if a.Required {
+				return -1
+			}
+			return 1
+		}
+		return b.Importance - a.Importance
+	})
+
+	for _, j := range job {
+		if j.required && !covered[taxonomy.SkillKey(j.skill)] {
+			result.Missing = append(result.Missing, j.skill.Name)
+		}
+	}
+	return result
+}
+
+// counts reports whether listing own satisfies the job skill: the same skill, or a more
+// specific one (EKS for Kubernetes).
+func (m *Matcher) counts(own, job types.ExtractedSkill) bool {
+	if taxonomy.SkillKey(own) == taxonomy.SkillKey(job) {
+		return true
+	}
+	if own.ID == "" || job.ID == "" {
+		return false
+	}
+	return slices.Contains(m.taxonomy.Ancestors(own.ID), job.ID)
+}
@@ -0,0 +1,53 @@
+package matcher
+
+import "strings"
+
+// stem is a deliberately small suffix stripper: enough that "deployed", "deploying" and
+// "deploys" meet, without the surprises of a full Porter stemmer on tech terms.
+func stem(word string) string {
+	if len(word) <= 3 || !isAlpha(word) {
+		return word
+	}
+
+	switch {
+	case strings.HasSuffix(word, "ies") && len(word) > 4:
+		word = word[:len(word)-3] + "y"
+	case strings.HasSuffix(word, "ied") && len(word) > 4:
+		word = word[:len(word)-3] + "y"
+	case strings.HasSuffix(word, "ments") && len(word) > 7:
+		word = word[:len(word)-5]
+	case strings.HasSuffix(word, "ment") && len(word) > 6:
+		word = word[:len(word)-4]
+	case strings.HasSuffix(word, "ing") && len(word) > 5:
+		word = undouble(word[:len(word)-3])
+	case strings.HasSuffix(word, "ed") && len(word) > 4:
+		word = undouble(word[:len(word)-2])
+	case strings.HasSuffix(word, "sses"), strings.HasSuffix(word, "xes"), strings.HasSuffix(word, "ches"), strings.HasSuffix(word, "shes"):
+		word = word[:len(word)-2]
+	case strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") && !strings.HasSuffix(word, "us") && !strings.HasSuffix(word, "is"):
+		word = word[:len(word)-1]
+	}
+
+	// "manage" and "managing" should end up the same
+	if len(word) > 3 && strings.HasSuffix(word, "e") {
+		word = word[:len(word)-1]
+	}
+	return word
+}
+
+func undouble(word string) string {
+	n := len(word)
+	if n > 3 && word[n-1] == word[n-2] && !strings.ContainsRune("lsz", rune(word[n-1])) {
+		return word[:n-1]
+	}
+	return word
+}
+
+func isAlpha(s string) bool {
+	for _, r := range s {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,68 @@
+package reminders
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+// Notifier delivers a reminder that came due. An error means it wasn't delivered and
+// will be retried.
+type Notifier interface {
+	Notify(ctx context.Context, r types.Reminder) error
+}
+
+// WebhookNotifier POSTs each reminder as JSON to a URL (a Slack or ntfy bridge, a
+// home automation hook, ...). Any non-2xx response is a failure.
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{url: url, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, r types.Reminder) error {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("failed to encode reminder: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("invalid webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook failed: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// Notifiers sends to every notifier in turn and fails if any of them did. A retry sends
+// to all of them again, so they shouldn't mind a reminder arriving twice.
+type Notifiers []Notifier
+
+func (ns Notifiers) Notify(ctx context.Context, r types.Reminder) error {
+	var errs []error
+	for _, n := range ns {
+		if err := n.Notify(ctx, r); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
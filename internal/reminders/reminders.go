@@ -0,0 +1,206 @@
+package reminders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/p-shah256/tracker/internal/applications"
+	"github.com/p-shah256/tracker/internal/storage"
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+var ErrInvalid = errors.New("invalid reminder")
+
+const (
+	// maxAttempts failed deliveries give up on a reminder, it's marked fired with the last error
+	maxAttempts = 5
+	retryDelay  = 5 * time.Minute
+	// batchSize reminders are fired per tick at most, the rest wait for the next one
+	batchSize = 50
+)
+
+// Policy is when reminders are scheduled automatically.
+type Policy struct {
+	// FollowUpAfter applying, if the application hasn't moved on by then
+	FollowUpAfter time.Duration
+	// PrepBefore an interview
+	PrepBefore time.Duration
+}
+
+func DefaultPolicy() Policy {
+	return Policy{
+		FollowUpAfter: 7 * 24 * time.Hour,
+		PrepBefore:    24 * time.Hour,
+	}
+}
+
+// Scheduler keeps reminders in the repository and fires the ones that are due through
+// its notifier, and writes each to the server log once when it first comes due. Nothing is held in memory, so reminders survive restarts and any that
+// came due while the server was down fire on the first tick.
+type Scheduler struct {
+	repo     storage.Repository
+	notifier Notifier
+	policy   Policy
+	interval time.Duration
+}
+
+func NewScheduler(repo storage.Repository, notifier Notifier, policy Policy) *Scheduler {
+	return &Scheduler{repo: repo, notifier: notifier, policy: policy, interval: time.Minute}
+}
+
+// Run fires due reminders every minute until ctx is done.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		if err := s.Tick(ctx, time.Now()); err != nil {
+			slog.Error("Reminder tick failed", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Tick fires every pending reminder due by now. A failed delivery is retried after a
+// growing delay until maxAttempts, the reminder keeps its due date.
+func (s *Scheduler) Tick(ctx context.Context, now time.Time) error {
+	due, err := s.repo.ListReminders(ctx, storage.ReminderFilter{Pending: true, AttemptBefore: now, Limit: batchSize})
+	if err != nil {
+		return err
+	}
+	for _, r := range due {
+		r.Attempts++
+		// logged outside the notifier, a retry of a failed delivery doesn't log it again
+		if r.Attempts == 1 {
+			slog.Info("Reminder due",
+				"reminder_id", r.ID,
+				"application_id", r.ApplicationID,
+				"kind", r.Kind,
+				"company", r.Company,
+				"position", r.Position,
+				"message", r.Message,
+				"due_at", r.DueAt)
+		}
+		if err := s.notifier.Notify(ctx, r); err != nil {
+			slog.Warn("Reminder delivery failed", "err", err, "reminder_id", r.ID, "attempt", r.Attempts)
+			r.LastError = err.Error()
+			if r.Attempts < maxAttempts {
+				next := now.Add(time.Duration(r.Attempts) * retryDelay)
+				r.NextAttemptAt = &next
+			} else {
+				r.NextAttemptAt = nil
+				r.FiredAt = &now
+			}
+		} else {
+			r.LastError = ""
+			r.NextAttemptAt = nil
+			r.FiredAt = &now
+		}
+		if err := s.repo.UpdateReminder(ctx, &r); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Schedule adds a reminder to an application.
+func (s *Scheduler) Schedule(ctx context.Context, applicationID string, req types.ReminderRequest) (*types.Reminder, error) {
+	r := &types.Reminder{
+		ApplicationID: applicationID,
+		Kind:          req.Kind,
+		Message:       strings.TrimSpace(req.Message),
+	}
+	if r.Kind == "" {
+		r.Kind = types.ReminderCustom
+	}
+	if !slices.Contains([]types.ReminderKind{types.ReminderFollowUp, types.ReminderInterviewPrep, types.ReminderCustom}, r.Kind) {
+		return nil, fmt.Errorf("%w: unknown kind %q", ErrInvalid, req.Kind)
+	}
+	switch {
+	case req.DueAt != nil && req.InDays != 0:
+		return nil, fmt.Errorf("%w: give either due_at or in_days, not both", ErrInvalid)
+	case req.DueAt != nil:
+		r.DueAt = *req.DueAt
+	case req.InDays > 0:
+		r.DueAt = time.Now().AddDate(0, 0, req.InDays)
+	default:
+		return nil, fmt.Errorf("%w: due_at or a positive in_days is required", ErrInvalid)
+	}
+	if r.Message == "" {
+		return nil, fmt.Errorf("%w: message is required", ErrInvalid)
+	}
+
+	if err := s.repo.CreateReminder(ctx, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (s *Scheduler) List(ctx context.Context, filter storage.ReminderFilter) ([]types.Reminder, error) {
+	return s.repo.ListReminders(ctx, filter)
+}
+
+func (s *Scheduler) Delete(ctx context.Context, id string) error {
+	return s.repo.DeleteReminder(ctx, id)
+}
+
+// StatusChanged keeps the automatic reminders in line with an application that just
+// changed status: applying schedules a follow-up, any reply cancels it, and closing
+// cancels everything automatic.
+func (s *Scheduler) StatusChanged(ctx context.Context, app *types.Application) error {
+	var cancel []types.ReminderKind
+	switch {
+	case applications.Closed(app.Status):
+		cancel = []types.ReminderKind{types.ReminderFollowUp, types.ReminderInterviewPrep}
+	case app.Status != types.StatusSaved:
+		cancel = []types.ReminderKind{types.ReminderFollowUp}
+	}
+	if _, err := s.repo.CancelReminders(ctx, app.ID, cancel...); err != nil {
+		return err
+	}
+
+	if app.Status != types.StatusApplied || s.policy.FollowUpAfter <= 0 {
+		return nil
+	}
+	appliedAt := time.Now()
+	if len(app.History) > 0 {
+		appliedAt = app.History[len(app.History)-1].At
+	}
+	return s.repo.CreateReminder(ctx, &types.Reminder{
+		ApplicationID: app.ID,
+		Kind:          types.ReminderFollowUp,
+		Message:       fmt.Sprintf("Follow up with %s about %s, no reply since applying on %s", app.Company, app.Position, appliedAt.Format(time.DateOnly)),
+		DueAt:         appliedAt.Add(s.policy.FollowUpAfter),
+	})
+}
+
+// InterviewScheduled (re)schedules the prep reminder for a new or moved interview.
+func (s *Scheduler) InterviewScheduled(ctx context.Context, interview *types.Interview) error {
+	if err := s.repo.CancelInterviewReminders(ctx, interview.ID); err != nil {
+		return err
+	}
+	if s.policy.PrepBefore <= 0 || interview.StartsAt.Before(time.Now()) {
+		return nil
+	}
+	return s.repo.CreateReminder(ctx, &types.Reminder{
+		ApplicationID: interview.ApplicationID,
+		InterviewID:   interview.ID,
+		Kind:          types.ReminderInterviewPrep,
+		Message: fmt.Sprintf("Prepare for %s with %s (%s) on %s", interview.Title, interview.Company, interview.Position,
+			interview.StartsAt.Format("Mon Jan 2 15:04 MST")),
+		DueAt: interview.StartsAt.Add(-s.policy.PrepBefore),
+	})
+}
+
+// PrepBefore is how long before an interview its prep reminder fires.
+func (s *Scheduler) PrepBefore() time.Duration {
+	return s.policy.PrepBefore
+}
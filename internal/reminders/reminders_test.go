@@ -0,0 +1,212 @@
+package reminders
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/p-shah256/tracker/internal/storage"
+	"github.com/p-shah256/tracker/internal/storage/storagetest"
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+// flakyNotifier fails the first failures deliveries.
+type flakyNotifier struct {
+	failures  int
+	delivered []types.Reminder
+}
+
+func (n *flakyNotifier) Notify(_ context.Context, r types.Reminder) error {
+	if n.failures > 0 {
+		n.failures--
+		return errors.New("webhook down")
+	}
+	n.delivered = append(n.delivered, r)
+	return nil
+}
+
+func setup(t *testing.T, notifier Notifier) (*Scheduler, storage.Repository, *types.Application) {
+	t.Helper()
+	store := storagetest.Open(t)
+	app := &types.Application{Company: "Globex", Position: "Engineer", Status: types.StatusSaved}
+	if err := store.CreateApplication(context.Background(), app); err != nil {
+		t.Fatal(err)
+	}
+	return NewScheduler(store, notifier, DefaultPolicy()), store, app
+}
+
+func TestTickRetriesKeepDueAt(t *testing.T) {
+	ctx := context.Background()
+	notifier := &flakyNotifier{failures: 2}
+	s, store, app := setup(t, notifier)
+
+	dueAt := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	r, err := s.Schedule(ctx, app.ID, types.ReminderRequest{Message: "Send the take-home", DueAt: &dueAt})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pending := func() types.Reminder {
+		t.Helper()
+		reminders, err := store.ListReminders(ctx, storage.ReminderFilter{ApplicationID: app.ID})
+		if err != nil || len(reminders) != 1 {
+			t.Fatalf("reminders = %+v, %v", reminders, err)
+		}
+		return reminders[0]
+	}
+
+	if err := s.Tick(ctx, dueAt.Add(-time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if got := pending(); got.Attempts != 0 {
+		t.Fatalf("reminder was attempted before it was due: %+v", got)
+	}
+
+	now := dueAt.Add(time.Minute)
+	if err := s.Tick(ctx, now); err != nil {
+		t.Fatal(err)
+	}
+	got := pending()
+	if got.Attempts != 1 || got.LastError != "webhook down" || got.FiredAt != nil {
+		t.Fatalf("after a failure = %+v", got)
+	}
+	if !got.DueAt.Equal(dueAt) {
+		t.Errorf("due_at moved to %v, want %v", got.DueAt, dueAt)
+	}
+	if got.NextAttemptAt == nil || !got.NextAttemptAt.Equal(now.Add(retryDelay)) {
+		t.Errorf("next_attempt_at = %v, want %v", got.NextAttemptAt, now.Add(retryDelay))
+	}
+
+	// not yet time for the retry
+	if err := s.Tick(ctx, now.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if got := pending(); got.Attempts != 1 {
+		t.Errorf("retried early: %+v", got)
+	}
+
+	now = now.Add(retryDelay)
+	if err := s.Tick(ctx, now); err != nil {
+		t.Fatal(err)
+	}
+	if got := pending(); got.Attempts != 2 || !got.NextAttemptAt.Equal(now.Add(2*retryDelay)) {
+		t.Errorf("second failure = %+v, want the delay to grow", got)
+	}
+
+	now = now.Add(2 * retryDelay)
+	if err := s.Tick(ctx, now); err != nil {
+		t.Fatal(err)
+	}
+	got = pending()
+	if got.FiredAt == nil || got.Attempts != 3 || got.LastError != "" || got.NextAttemptAt != nil || !got.DueAt.Equal(dueAt) {
+		t.Errorf("delivered reminder = %+v", got)
+	}
+	if len(notifier.delivered) != 1 || notifier.delivered[0].ID != r.ID {
+		t.Errorf("delivered = %+v", notifier.delivered)
+	}
+
+	// fired reminders are done
+	if err := s.Tick(ctx, now.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if len(notifier.delivered) != 1 {
+		t.Errorf("reminder fired twice")
+	}
+}
+
+func TestTickGivesUp(t *testing.T) {
+	ctx := context.Background()
+	s, store, app := setup(t, &flakyNotifier{failures: maxAttempts})
+	dueAt := time.Now().Add(-time.Hour)
+	if _, err := s.Schedule(ctx, app.ID, types.ReminderRequest{Message: "Follow up", DueAt: &dueAt}); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	for range maxAttempts {
+		if err := s.Tick(ctx, now); err != nil {
+			t.Fatal(err)
+		}
+		now = now.Add(time.Duration(maxAttempts) * retryDelay)
+	}
+	reminders, err := store.ListReminders(ctx, storage.ReminderFilter{ApplicationID: app.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := reminders[0]; r.FiredAt == nil || r.Attempts != maxAttempts || r.LastError == "" {
+		t.Errorf("reminder = %+v, want it given up on with the last error", r)
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	s, _, app := setup(t, &flakyNotifier{})
+	dueAt := time.Now().Add(time.Hour)
+	tests := []struct {
+		name string
+		req  types.ReminderRequest
+		ok   bool
+	}{
+		{"due at", types.ReminderRequest{Message: "Call back", DueAt: &dueAt}, true},
+		{"in days", types.ReminderRequest{Message: "Call back", InDays: 2, Kind: types.ReminderFollowUp}, true},
+		{"both", types.ReminderRequest{Message: "Call back", DueAt: &dueAt, InDays: 2}, false},
+		{"neither", types.ReminderRequest{Message: "Call back"}, false},
+		{"no message", types.ReminderRequest{Message: " ", InDays: 1}, false},
+		{"unknown kind", types.ReminderRequest{Message: "Call back", InDays: 1, Kind: "nag"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.Schedule(context.Background(), app.ID, tt.req)
+			if (err == nil) != tt.ok {
+				t.Fatalf("Schedule() = %v, want ok %v", err, tt.ok)
+			}
+			if err != nil && !errors.Is(err, ErrInvalid) {
+				t.Errorf("error %v isn't ErrInvalid", err)
+			}
+		})
+	}
+}
+
+func TestStatusChanged(t *testing.T) {
+	ctx := context.Background()
+	s, store, app := setup(t, &flakyNotifier{})
+	appliedAt := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	app.Status = types.StatusApplied
+	app.History = []types.StatusChange{{From: types.StatusSaved, To: types.StatusApplied, At: appliedAt}}
+	if err := s.StatusChanged(ctx, app); err != nil {
+		t.Fatal(err)
+	}
+	reminders, _ := store.ListReminders(ctx, storage.ReminderFilter{ApplicationID: app.ID})
+	if len(reminders) != 1 || reminders[0].Kind != types.ReminderFollowUp || !reminders[0].DueAt.Equal(appliedAt.Add(DefaultPolicy().FollowUpAfter)) {
+		t.Fatalf("reminders = %+v, want a follow-up a week after applying", reminders)
+	}
+
+	app.Status = types.StatusScreening
+	if err := s.StatusChanged(ctx, app); err != nil {
+		t.Fatal(err)
+	}
+	if reminders, _ := store.ListReminders(ctx, storage.ReminderFilter{ApplicationID: app.ID}); len(reminders) != 0 {
+		t.Errorf("a reply didn't cancel the follow-up: %+v", reminders)
+	}
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Content-Type = %q", r.Header.Get("Content-Type"))
+		}
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	n := NewWebhookNotifier(srv.URL)
+	if err := n.Notify(context.Background(), types.Reminder{ID: "r1"}); err != nil {
+		t.Errorf("Notify() = %v", err)
+	}
+	status = http.StatusBadGateway
+	if err := n.Notify(context.Background(), types.Reminder{ID: "r1"}); err == nil {
+		t.Error("a 502 counted as delivered")
+	}
+}
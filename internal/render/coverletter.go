@@ -0,0 +1,73 @@
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/go-pdf/fpdf"
+
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+// RenderCoverLetter writes a cover letter as Markdown or PDF, it has no HTML themes.
+func RenderCoverLetter(w io.Writer, letter *types.CoverLetter, format Format) error {
+	switch format {
+	case FormatMarkdown:
+		return CoverLetterMarkdown(w, letter)
+	case FormatPDF:
+		return CoverLetterPDF(w, letter)
+	}
+	return fmt.Errorf("cover letters can't be rendered as %q", format)
+}
+
+func CoverLetterMarkdown(w io.Writer, letter *types.CoverLetter) error {
+	bw := bufio.NewWriter(w)
+	if letter.Greeting != "" {
+		fmt.Fprintf(bw, "%s\n\n", letter.Greeting)
+	}
+	for _, p := range letter.Paragraphs {
+		fmt.Fprintf(bw, "%s\n\n", p)
+	}
+	if letter.Closing != "" {
+		// two trailing spaces keep the signature on its own line
+		fmt.Fprintf(bw, "%s  \n", letter.Closing)
+	}
+	if letter.Signature != "" {
+		fmt.Fprintf(bw, "%s\n", letter.Signature)
+	}
+	return bw.Flush()
+}
+
+// CoverLetterPDF uses the same page and fonts as the resume PDF.
+func CoverLetterPDF(w io.Writer, letter *types.CoverLetter) error {
+	pdf := fpdf.New("P", "mm", "Letter", "")
+	pdf.SetMargins(pdfMargin+7, pdfMargin+7, pdfMargin+7)
+	pdf.SetAutoPageBreak(true, pdfMargin)
+	pdf.SetTitle("Cover letter - "+letter.Company, true)
+	pdf.AddPage()
+
+	text := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.SetFont("Helvetica", "", 11)
+	const lineHeight = pdfLineHeight + 1
+
+	if letter.Greeting != "" {
+		pdf.MultiCell(0, lineHeight, text(letter.Greeting), "", "L", false)
+		pdf.Ln(lineHeight)
+	}
+	for _, p := range letter.Paragraphs {
+		pdf.MultiCell(0, lineHeight, text(p), "", "L", false)
+		pdf.Ln(lineHeight)
+	}
+	if letter.Closing != "" {
+		pdf.MultiCell(0, lineHeight, text(letter.Closing), "", "L", false)
+	}
+	if letter.Signature != "" {
+		pdf.MultiCell(0, lineHeight, text(letter.Signature), "", "L", false)
+	}
+
+	if err := pdf.Output(w); err != nil {
+		return fmt.Errorf("failed to write PDF: %w", err)
+	}
+	return nil
+}
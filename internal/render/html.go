@@ -0,0 +1,84 @@
+package render
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+const DefaultTheme = "classic"
+
+//go:embed themes/*.html
+var themeFS embed.FS
+
+var (
+	themesMu sync.RWMutex
+	themes   = map[string]*template.Template{}
+)
+
+var templateFuncs = template.FuncMap{
+	"join": strings.Join,
+	"meta": entryMeta,
+}
+
+func init() {
+	files, err := themeFS.ReadDir("themes")
+	if err != nil {
+		panic(fmt.Sprintf("render: cannot read embedded themes: %v", err))
+	}
+	for _, f := range files {
+		name := strings.TrimSuffix(f.Name(), path.Ext(f.Name()))
+		tmpl := template.Must(template.New(f.Name()).Funcs(templateFuncs).ParseFS(themeFS, "themes/"+f.Name()))
+		themes[name] = tmpl
+	}
+}
+
+// RegisterTheme makes a theme available to HTML. The template is executed with a
+// *types.Resume and must produce a complete standalone document. Use ParseTheme
+// to get a template with the helper funcs the built-in themes use.
+func RegisterTheme(name string, tmpl *template.Template) {
+	themesMu.Lock()
+	defer themesMu.Unlock()
+	themes[name] = tmpl
+}
+
+func ParseTheme(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(templateFuncs).Parse(text)
+}
+
+func Themes() []string {
+	themesMu.RLock()
+	defer themesMu.RUnlock()
+
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func HTML(w io.Writer, r *types.Resume, theme string) error {
+	if theme == "" {
+		theme = DefaultTheme
+	}
+
+	themesMu.RLock()
+	tmpl, ok := themes[theme]
+	themesMu.RUnlock()
+	if !ok {
+		return fmt.Errorf("unknown theme %q", theme)
+	}
+
+	if err := tmpl.Execute(w, r); err != nil {
+		return fmt.Errorf("failed to render theme %q: %w", theme, err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+func Markdown(w io.Writer, r *types.Resume) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "# %s\n\n", r.Name)
+	if r.Headline != "" {
+		fmt.Fprintf(bw, "**%s**\n\n", r.Headline)
+	}
+	if len(r.Contact) > 0 {
+		fmt.Fprintf(bw, "%s\n\n", strings.Join(r.Contact, " | "))
+	}
+	if r.Summary != "" {
+		fmt.Fprintf(bw, "## Summary\n\n%s\n\n", r.Summary)
+	}
+
+	for _, section := range r.Sections {
+		fmt.Fprintf(bw, "## %s\n\n", section.Title)
+		for _, entry := range section.Entries {
+			fmt.Fprintf(bw, "### %s\n\n", entry.Name)
+			if meta := entryMeta(entry); meta != "" {
+				fmt.Fprintf(bw, "*%s*\n\n", meta)
+			}
+			for _, bullet := range entry.Bullets {
+				fmt.Fprintf(bw, "- %s\n", bullet)
+			}
+			if len(entry.Bullets) > 0 {
+				bw.WriteString("\n")
+			}
+		}
+	}
+
+	if len(r.Skills) > 0 {
+		fmt.Fprintf(bw, "## Skills\n\n%s\n", strings.Join(r.Skills, ", "))
+	}
+
+	return bw.Flush()
+}
+
+func entryMeta(e types.ResumeEntry) string {
+	var parts []string
+	if e.Subtitle != "" {
+		parts = append(parts, e.Subtitle)
+	}
+	if e.Dates != "" {
+		parts = append(parts, e.Dates)
+	}
+	return strings.Join(parts, " — ")
+}
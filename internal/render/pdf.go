@@ -0,0 +1,91 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+const (
+	pdfMargin     = 18.0
+	pdfLineHeight = 5.0
+)
+
+// PDF lays the resume out on US Letter with the core Helvetica fonts, so no font
+// files are needed at runtime.
+func PDF(w io.Writer, r *types.Resume) error {
+	pdf := fpdf.New("P", "mm", "Letter", "")
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(true, pdfMargin)
+	pdf.SetTitle(r.Name, true)
+	pdf.AddPage()
+
+	// core fonts are cp1252, resume text is UTF-8
+	text := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.SetFont("Helvetica", "B", 20)
+	pdf.CellFormat(0, 10, text(r.Name), "", 1, "C", false, 0, "")
+	if r.Headline != "" {
+		pdf.SetFont("Helvetica", "I", 11)
+		pdf.CellFormat(0, 6, text(r.Headline), "", 1, "C", false, 0, "")
+	}
+	if len(r.Contact) > 0 {
+		pdf.SetFont("Helvetica", "", 9)
+		pdf.MultiCell(0, pdfLineHeight, text(strings.Join(r.Contact, " | ")), "", "C", false)
+	}
+
+	if r.Summary != "" {
+		pdfHeading(pdf, text("Summary"))
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.MultiCell(0, pdfLineHeight, text(r.Summary), "", "L", false)
+	}
+
+	for _, section := range r.Sections {
+		pdfHeading(pdf, text(section.Title))
+		for _, entry := range section.Entries {
+			pdf.Ln(1)
+			pdf.SetFont("Helvetica", "B", 11)
+			pdf.MultiCell(0, pdfLineHeight+1, text(entry.Name), "", "L", false)
+			if meta := entryMeta(entry); meta != "" {
+				pdf.SetFont("Helvetica", "I", 9)
+				pdf.MultiCell(0, pdfLineHeight, text(meta), "", "L", false)
+			}
+			pdf.SetFont("Helvetica", "", 10)
+			for _, bullet := range entry.Bullets {
+				pdfBullet(pdf, text(bullet))
+			}
+		}
+	}
+
+	if len(r.Skills) > 0 {
+		pdfHeading(pdf, text("Skills"))
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.MultiCell(0, pdfLineHeight, text(strings.Join(r.Skills, ", ")), "", "L", false)
+	}
+
+	if err := pdf.Output(w); err != nil {
+		return fmt.Errorf("failed to write PDF: %w", err)
+	}
+	return nil
+}
+
+func pdfHeading(pdf *fpdf.Fpdf, title string) {
+	pdf.Ln(3)
+	pdf.SetFont("Helvetica", "B", 12)
+	pdf.CellFormat(0, 7, strings.ToUpper(title), "B", 1, "L", false, 0, "")
+	pdf.Ln(1)
+}
+
+func pdfBullet(pdf *fpdf.Fpdf, bullet string) {
+	const indent = 5.0
+	left, _, right, _ := pdf.GetMargins()
+	pageWidth, _ := pdf.GetPageSize()
+
+	pdf.SetX(left)
+	pdf.CellFormat(indent, pdfLineHeight, "\x95", "", 0, "C", false, 0, "")
+	pdf.MultiCell(pageWidth-left-right-indent, pdfLineHeight, bullet, "", "L", false)
+}
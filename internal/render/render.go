@@ -0,0 +1,98 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+type Format string
+
+const (
+	FormatMarkdown Format = "markdown"
+	FormatHTML     Format = "html"
+	FormatPDF      Format = "pdf"
+)
+
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "md", "markdown":
+		return FormatMarkdown, nil
+	case "html", "htm":
+		return FormatHTML, nil
+	case "pdf":
+		return FormatPDF, nil
+	}
+	return "", fmt.Errorf("unsupported format %q", s)
+}
+
+func (f Format) ContentType() string {
+	switch f {
+	case FormatHTML:
+		return "text/html; charset=utf-8"
+	case FormatPDF:
+		return "application/pdf"
+	default:
+		return "text/markdown; charset=utf-8"
+	}
+}
+
+func (f Format) Extension() string {
+	if f == FormatMarkdown {
+		return "md"
+	}
+	return string(f)
+}
+
+// Render writes the resume in the requested format. theme only applies to HTML,
+// empty means DefaultTheme.
+func Render(w io.Writer, r *types.Resume, format Format, theme string) error {
+	switch format {
+	case FormatMarkdown:
+		return Markdown(w, r)
+	case FormatHTML:
+		return HTML(w, r, theme)
+	case FormatPDF:
+		return PDF(w, r)
+	}
+	return fmt.Errorf("unsupported format %q", format)
+}
+
+// ApplyBullets returns a copy of the resume where every bullet that matches the
+// original_bullet of a chosen item is swapped for its transformed_bullet.
+func ApplyBullets(r types.Resume, chosen []types.TransformedItem) types.Resume {
+	replacements := make(map[string]string, len(chosen))
+	for _, item := range chosen {
+		if item.TransformedBullet == "" {
+			continue
+		}
+		replacements[normalize(item.OriginalBullet)] = item.TransformedBullet
+	}
+
+	out := r
+	out.Sections = make([]types.ResumeSection, len(r.Sections))
+	for i, section := range r.Sections {
+		out.Sections[i] = section
+		out.Sections[i].Entries = make([]types.ResumeEntry, len(section.Entries))
+		for j, entry := range section.Entries {
+			bullets := make([]string, len(entry.Bullets))
+			for k, bullet := range entry.Bullets {
+				if replacement, ok := replacements[normalize(bullet)]; ok {
+					bullet = replacement
+				}
+				bullets[k] = bullet
+			}
+			entry.Bullets = bullets
+			out.Sections[i].Entries[j] = entry
+		}
+	}
+	return out
+}
+
+func normalize(s string) string {
+	s = strings.TrimSpace(s)
+	s = strings.TrimLeft(s, "-•*· ")
+	return strings.Join(strings.Fields(s), " ")
+}
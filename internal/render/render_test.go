@@ -0,0 +1,179 @@
+package render
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+func testResume() *types.Resume {
+	return &types.Resume{
+		Name:     "Ada Lovelace",
+		Headline: "Backend Engineer",
+		Contact:  []string{"ada@example.com", "London"},
+		Summary:  "Builds reliable services.",
+		Sections: []types.ResumeSection{{
+			Title: "Experience",
+			Entries: []types.ResumeEntry{{
+				Name:     "Acme-Engineer",
+				Subtitle: "Acme Corp",
+				Dates:    "2020 – 2024",
+				Bullets:  []string{"Built the <billing> pipeline in Go", "Cut p99 latency by 40%"},
+			}},
+		}},
+		Skills: []string{"Go", "PostgreSQL"},
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Format
+		wantErr bool
+	}{
+		{"", FormatMarkdown, false},
+		{"md", FormatMarkdown, false},
+		{" Markdown ", FormatMarkdown, false},
+		{"HTML", FormatHTML, false},
+		{"htm", FormatHTML, false},
+		{"pdf", FormatPDF, false},
+		{"docx", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseFormat(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseFormat(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseFormat(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestApplyBullets(t *testing.T) {
+	tests := []struct {
+		name   string
+		chosen []types.TransformedItem
+		want   []string
+	}{
+		{
+			name:   "no items",
+			chosen: nil,
+			want:   []string{"Built the <billing> pipeline in Go", "Cut p99 latency by 40%"},
+		},
+		{
+			name:   "matches ignoring bullet marker and spacing",
+			chosen: []types.TransformedItem{{OriginalBullet: "•  Cut p99  latency by 40%", TransformedBullet: "Cut p99 latency 40% by caching"}},
+			want:   []string{"Built the <billing> pipeline in Go", "Cut p99 latency 40% by caching"},
+		},
+		{
+			name:   "empty transformation is skipped",
+			chosen: []types.TransformedItem{{OriginalBullet: "Cut p99 latency by 40%"}},
+			want:   []string{"Built the <billing> pipeline in Go", "Cut p99 latency by 40%"},
+		},
+		{
+			name:   "unknown bullet is ignored",
+			chosen: []types.TransformedItem{{OriginalBullet: "Wrote docs", TransformedBullet: "Wrote great docs"}},
+			want:   []string{"Built the <billing> pipeline in Go", "Cut p99 latency by 40%"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := testResume()
+			got := ApplyBullets(*original, tt.chosen)
+			bullets := got.Sections[0].Entries[0].Bullets
+			if strings.Join(bullets, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("bullets = %q, want %q", bullets, tt.want)
+			}
+			if original.Sections[0].Entries[0].Bullets[1] != "Cut p99 latency by 40%" {
+				t.Error("ApplyBullets modified the original resume")
+			}
+		})
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Markdown(&buf, testResume()); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"# Ada Lovelace\n",
+		"**Backend Engineer**",
+		"ada@example.com | London",
+		"## Experience\n",
+		"*Acme Corp — 2020 – 2024*",
+		"- Cut p99 latency by 40%\n",
+		"## Skills\n\nGo, PostgreSQL\n",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Markdown output is missing %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestHTML(t *testing.T) {
+	for _, theme := range Themes() {
+		t.Run(theme, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := HTML(&buf, testResume(), theme); err != nil {
+				t.Fatal(err)
+			}
+			out := buf.String()
+			if !strings.Contains(out, "Ada Lovelace") {
+				t.Error("name missing from output")
+			}
+			if strings.Contains(out, "<billing>") || !strings.Contains(out, "&lt;billing&gt;") {
+				t.Error("bullet text isn't escaped")
+			}
+		})
+	}
+
+	if err := HTML(&bytes.Buffer{}, testResume(), "nope"); err == nil {
+		t.Error("unknown theme rendered without error")
+	}
+}
+
+func TestPDF(t *testing.T) {
+	var buf bytes.Buffer
+	if err := PDF(&buf, testResume()); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")) {
+		t.Errorf("output doesn't start with a PDF header: %q", buf.Bytes()[:min(buf.Len(), 8)])
+	}
+}
+
+func TestCoverLetter(t *testing.T) {
+	letter := &types.CoverLetter{
+		Company:    "Globex",
+		Greeting:   "Dear Hiring Team,",
+		Paragraphs: []string{"First paragraph.", "Second paragraph."},
+		Closing:    "Best regards,",
+		Signature:  "Ada Lovelace",
+	}
+
+	var md bytes.Buffer
+	if err := RenderCoverLetter(&md, letter, FormatMarkdown); err != nil {
+		t.Fatal(err)
+	}
+	want := "Dear Hiring Team,\n\nFirst paragraph.\n\nSecond paragraph.\n\nBest regards,  \nAda Lovelace\n"
+	if md.String() != want {
+		t.Errorf("Markdown = %q, want %q", md.String(), want)
+	}
+
+	var pdf bytes.Buffer
+	if err := RenderCoverLetter(&pdf, letter, FormatPDF); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(pdf.Bytes(), []byte("%PDF-")) {
+		t.Error("output doesn't start with a PDF header")
+	}
+
+	if err := RenderCoverLetter(&bytes.Buffer{}, letter, FormatHTML); err == nil {
+		t.Error("cover letter rendered as HTML without error")
+	}
+}
@@ -0,0 +1,310 @@
+package sheets
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(strings.TrimSpace(s))); f {
+	case FormatCSV, FormatXLSX:
+		return f, nil
+	case "":
+		return FormatCSV, nil
+	}
+	return "", fmt.Errorf("%w: format must be csv or xlsx, got %q", ErrInvalid, s)
+}
+
+func (f Format) ContentType() string {
+	if f == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Export writes applications as a spreadsheet, one row each under a header row.
+func Export(w io.Writer, apps []types.Application, format Format) error {
+	if format == FormatXLSX {
+		return WriteXLSX(w, apps)
+	}
+	return WriteCSV(w, apps)
+}
+
+type cellKind int
+
+const (
+	textCell cellKind = iota
+	numberCell
+	// dateCell is a day, timeCell a moment (written in UTC)
+	dateCell
+	timeCell
+)
+
+type cell struct {
+	kind   cellKind
+	text   string
+	number float64
+	time   time.Time
+}
+
+func (c cell) empty() bool {
+	switch c.kind {
+	case numberCell:
+		return false
+	case dateCell, timeCell:
+		return c.time.IsZero()
+	}
+	return c.text == ""
+}
+
+func text(s string) cell { return cell{kind: textCell, text: s} }
+
+func moment(t *time.Time, kind cellKind) cell {
+	if t == nil {
+		return cell{kind: kind}
+	}
+	return cell{kind: kind, time: t.UTC()}
+}
+
+type column struct {
+	name string
+	// width is the XLSX column width in characters
+	width int
+	value func(app *types.Application) cell
+}
+
+// columns are exported in this order. Their names are import fields where there is
+// one, so an export imports back as it is.
+var columns = []column{
+	{"id", 38, func(app *types.Application) cell { return text(app.ID) }},
+	{"company", 24, func(app *types.Application) cell { return text(app.Company) }},
+	{"position", 32, func(app *types.Application) cell { return text(app.Position) }},
+	{"level", 12, func(app *types.Application) cell { return text(app.Level) }},
+	{"company_size", 12, func(app *types.Application) cell { return text(app.CompanySize) }},
+	{"status", 14, func(app *types.Application) cell { return text(string(app.Status)) }},
+	{"score", 8, func(app *types.Application) cell {
+		if app.Score == nil {
+			return text("")
+		}
+		return cell{kind: numberCell, number: *app.Score}
+	}},
+	{"url", 40, func(app *types.Application) cell { return text(app.URL) }},
+	{"created_at", 18, func(app *types.Application) cell { return moment(&app.CreatedAt, timeCell) }},
+	{"applied_at", 18, func(app *types.Application) cell { return moment(appliedAt(app), timeCell) }},
+	{"status_changed_at", 18, func(app *types.Application) cell { return moment(statusChangedAt(app), timeCell) }},
+	{"deadline", 12, func(app *types.Application) cell { return moment(app.Deadline, dateCell) }},
+	{"user_id", 14, func(app *types.Application) cell { return text(app.UserID) }},
+	{"notes", 48, func(app *types.Application) cell { return text(app.Notes) }},
+}
+
+// appliedAt is when the application first went out, nil while it's only saved.
+func appliedAt(app *types.Application) *time.Time {
+	for _, change := range app.History {
+		if change.To == types.StatusApplied {
+			return &change.At
+		}
+	}
+	return nil
+}
+
+func statusChangedAt(app *types.Application) *time.Time {
+	if len(app.History) == 0 {
+		return nil
+	}
+	return &app.History[len(app.History)-1].At
+}
+
+func WriteCSV(w io.Writer, apps []types.Application) error {
+	cw := csv.NewWriter(w)
+	record := make([]string, len(columns))
+	for i, col := range columns {
+		record[i] = col.name
+	}
+	cw.Write(record)
+	for i := range apps {
+		for j, col := range columns {
+			record[j] = csvValue(col.value(&apps[i]))
+		}
+		cw.Write(record)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func csvValue(c cell) string {
+	if c.empty() {
+		return ""
+	}
+	switch c.kind {
+	case numberCell:
+		return strconv.FormatFloat(c.number, 'f', -1, 64)
+	case dateCell:
+		return c.time.Format(time.DateOnly)
+	case timeCell:
+		return c.time.Format(time.RFC3339)
+	}
+	if strings.ContainsAny(c.text[:1], formulaStart) {
+		return "'" + c.text
+	}
+	return c.text
+}
+
+// formulaStart are the characters that make a spreadsheet read a CSV cell as a formula.
+// Such text cells are written with a leading apostrophe so a note like "=HYPERLINK(...)"
+// stays text (OWASP CSV injection); the XLSX writer stores all text as inline strings,
+// which are never evaluated.
+const formulaStart = "=+-@\t\r"
+
+// cell styles, indexes into cellXfs of xlsxStyles
+const (
+	styleDefault = iota
+	styleHeader
+	styleDate
+	styleTime
+)
+
+// excelEpoch is day 0 of Excel's 1900 date system (off by the 1900 leap day bug, which
+// only matters before March 1900).
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// WriteXLSX writes a single sheet workbook. It's the minimal set of parts Excel,
+// LibreOffice and Google Sheets open without complaint: strings are inline, dates are
+// serial numbers with a date format, and the header row is bold and frozen.
+func WriteXLSX(w io.Writer, apps []types.Application) error {
+	zw := zip.NewWriter(w)
+	parts := []struct{ name, body string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRels},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for _, part := range parts {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, part.body); err != nil {
+			return err
+		}
+	}
+
+	f, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(f)
+	bw.WriteString(xml.Header)
+	bw.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	bw.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`)
+	bw.WriteString(`<cols>`)
+	for i, col := range columns {
+		fmt.Fprintf(bw, `<col min="%d" max="%d" width="%d" customWidth="1"/>`, i+1, i+1, col.width)
+	}
+	bw.WriteString(`</cols><sheetData>`)
+
+	bw.WriteString(`<row r="1">`)
+	for i, col := range columns {
+		writeCell(bw, cellRef(i, 1), text(col.name), styleHeader)
+	}
+	bw.WriteString(`</row>`)
+	for i := range apps {
+		row := i + 2
+		fmt.Fprintf(bw, `<row r="%d">`, row)
+		for j, col := range columns {
+			writeCell(bw, cellRef(j, row), col.value(&apps[i]), styleDefault)
+		}
+		bw.WriteString(`</row>`)
+	}
+	bw.WriteString(`</sheetData></worksheet>`)
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+func writeCell(w *bufio.Writer, ref string, c cell, style int) {
+	if c.empty() {
+		return
+	}
+	switch c.kind {
+	case numberCell:
+		fmt.Fprintf(w, `<c r="%s"><v>%s</v></c>`, ref, strconv.FormatFloat(c.number, 'f', -1, 64))
+	case dateCell, timeCell:
+		style = styleTime
+		if c.kind == dateCell {
+			style = styleDate
+		}
+		serial := c.time.Sub(excelEpoch).Hours() / 24
+		fmt.Fprintf(w, `<c r="%s" s="%d"><v>%s</v></c>`, ref, style, strconv.FormatFloat(serial, 'f', -1, 64))
+	default:
+		fmt.Fprintf(w, `<c r="%s" t="inlineStr"`, ref)
+		if style != styleDefault {
+			fmt.Fprintf(w, ` s="%d"`, style)
+		}
+		w.WriteString(`><is><t xml:space="preserve">`)
+		xml.EscapeText(w, []byte(c.text))
+		w.WriteString(`</t></is></c>`)
+	}
+}
+
+// cellRef names a cell A1-style, col counted from 0 and row from 1.
+func cellRef(col, row int) string {
+	name := ""
+	for col++; col > 0; col = (col - 1) / 26 {
+		name = string(rune('A'+(col-1)%26)) + name
+	}
+	return name + strconv.Itoa(row)
+}
+
+const xlsxContentTypes = xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+	`</Types>`
+
+const xlsxRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+const xlsxWorkbook = xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+	`<sheets><sheet name="Applications" sheetId="1" r:id="rId1"/></sheets>` +
+	`</workbook>`
+
+const xlsxWorkbookRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+	`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+	`</Relationships>`
+
+const xlsxStyles = xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<numFmts count="2"><numFmt numFmtId="164" formatCode="yyyy-mm-dd"/><numFmt numFmtId="165" formatCode="yyyy-mm-dd hh:mm"/></numFmts>` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="4">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="165" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`</cellXfs>` +
+	`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+	`</styleSheet>`
@@ -0,0 +1,366 @@
+package sheets
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/p-shah256/tracker/internal/applications"
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+var ErrInvalid = errors.New("invalid spreadsheet")
+
+// Mapping maps application fields to the spreadsheet columns they're read from. Fields
+// left out are matched to a column by name.
+type Mapping map[string]string
+
+// ParseMapping reads field=Column pairs.
+func ParseMapping(pairs []string) (Mapping, error) {
+	m := Mapping{}
+	for _, pair := range pairs {
+		name, column, ok := strings.Cut(pair, "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !ok || name == "" || strings.TrimSpace(column) == "" {
+			return nil, fmt.Errorf("%w: mapping %q must be field=Column", ErrInvalid, pair)
+		}
+		if findField(name) == nil {
+			return nil, fmt.Errorf("%w: unknown field %q, fields are %s", ErrInvalid, name, strings.Join(Fields(), ", "))
+		}
+		m[name] = strings.TrimSpace(column)
+	}
+	return m, nil
+}
+
+type ImportOptions struct {
+	Mapping Mapping
+	// UserID is given to every row that has no user_id column
+	UserID string
+	// DryRun checks every row without saving any
+	DryRun bool
+}
+
+type field struct {
+	name string
+	// aliases are other column names the field is recognised by, compared by columnKey
+	aliases []string
+	set     func(app *types.Application, value string) error
+}
+
+var fields = []field{
+	{"company", []string{"company name", "employer", "organization", "organisation"}, func(app *types.Application, v string) error {
+		app.Company = v
+		return nil
+	}},
+	{"position", []string{"role", "title", "job title", "job", "position title"}, func(app *types.Application, v string) error {
+		app.Position = v
+		return nil
+	}},
+	{"level", []string{"seniority"}, func(app *types.Application, v string) error {
+		app.Level = v
+		return nil
+	}},
+	{"company_size", []string{"size", "company size", "headcount"}, func(app *types.Application, v string) error {
+		size := strings.ToLower(v)
+		if !slices.Contains(applications.CompanySizes(), size) {
+			return fmt.Errorf("unknown company size, use one of %v", applications.CompanySizes())
+		}
+		app.CompanySize = size
+		return nil
+	}},
+	{"status", []string{"stage", "state"}, func(app *types.Application, v string) error {
+		status, err := parseStatus(v)
+		app.Status = status
+		return err
+	}},
+	{"score", []string{"fit", "match", "rating"}, func(app *types.Application, v string) error {
+		score, err := parseScore(v)
+		app.Score = &score
+		return err
+	}},
+	{"url", []string{"link", "job url", "posting", "job link"}, func(app *types.Application, v string) error {
+		app.URL = v
+		return nil
+	}},
+	{"notes", []string{"note", "comments", "comment"}, func(app *types.Application, v string) error {
+		app.Notes = v
+		return nil
+	}},
+	{"deadline", []string{"apply by", "closing date", "due"}, func(app *types.Application, v string) error {
+		t, err := parseDate(v)
+		app.Deadline = &t
+		return err
+	}},
+	{"created_at", []string{"created", "date", "date applied", "applied on", "applied date", "date added", "added"}, func(app *types.Application, v string) error {
+		t, err := parseDate(v)
+		app.CreatedAt = t
+		return err
+	}},
+	{"user_id", []string{"user", "owner"}, func(app *types.Application, v string) error {
+		app.UserID = v
+		return nil
+	}},
+}
+
+// Fields lists the application fields a spreadsheet column can be mapped to.
+func Fields() []string {
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = f.name
+	}
+	return names
+}
+
+func findField(name string) *field {
+	for i := range fields {
+		if fields[i].name == name {
+			return &fields[i]
+		}
+	}
+	return nil
+}
+
+var nonAlnum = regexp.MustCompile(`[^a-z0-9]+`)
+
+// columnKey compares column names ignoring case, spacing and punctuation, so
+// "Date Applied", "date_applied" and "date-applied" are the same column.
+func columnKey(name string) string {
+	return nonAlnum.ReplaceAllString(strings.ToLower(name), "")
+}
+
+// Import reads applications from CSV with a header row and creates the valid ones.
+// A row with any bad cell is reported and skipped, it never stops the others; only an
+// unreadable file, a bad mapping or a failing database stops the import. Rows created
+// before it stopped stay imported, the result returned with the error lists them.
+func Import(ctx context.Context, svc *applications.Service, r io.Reader, opts ImportOptions) (*types.ImportResult, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: the file is empty", ErrInvalid)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot read the header row: %v", ErrInvalid, err)
+	}
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+	index, result, err := mapColumns(header, opts.Mapping)
+	if err != nil {
+		return nil, err
+	}
+	result.DryRun = opts.DryRun
+
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			result.Rows++
+			result.Errors = append(result.Errors, types.ImportError{Row: parseErr.StartLine, Message: parseErr.Err.Error()})
+			continue
+		}
+		if err != nil {
+			return result, fmt.Errorf("%w: %v", ErrInvalid, err)
+		}
+		if blank(record) {
+			continue
+		}
+		result.Rows++
+		row, _ := cr.FieldPos(0)
+
+		app, rowErrs := readRow(record, header, index, row)
+		if len(rowErrs) > 0 {
+			result.Errors = append(result.Errors, rowErrs...)
+			continue
+		}
+		if app.UserID == "" {
+			app.UserID = opts.UserID
+		}
+		if opts.DryRun {
+			err = svc.Validate(ctx, &app)
+		} else {
+			err = svc.Create(ctx, &app)
+		}
+		if err != nil {
+			if !errors.Is(err, applications.ErrInvalid) {
+				return result, fmt.Errorf("row %d: %w", row, err)
+			}
+			result.Errors = append(result.Errors, types.ImportError{Row: row, Message: err.Error()})
+			continue
+		}
+		result.Applications = append(result.Applications, app)
+		if !opts.DryRun {
+			result.Imported++
+		}
+	}
+	return result, nil
+}
+
+// mapColumns finds each field's column, from the mapping or else by name.
+func mapColumns(header []string, mapping Mapping) (map[string]int, *types.ImportResult, error) {
+	index := map[string]int{}
+	result := &types.ImportResult{Columns: map[string]string{}, Errors: []types.ImportError{}, Applications: []types.Application{}}
+
+	for name, column := range mapping {
+		if findField(name) == nil {
+			return nil, nil, fmt.Errorf("%w: unknown field %q, fields are %s", ErrInvalid, name, strings.Join(Fields(), ", "))
+		}
+		i := slices.IndexFunc(header, func(h string) bool { return columnKey(h) == columnKey(column) })
+		if i < 0 {
+			return nil, nil, fmt.Errorf("%w: no column %q for %s", ErrInvalid, column, name)
+		}
+		index[name] = i
+	}
+	for _, f := range fields {
+		if _, ok := index[f.name]; ok {
+			continue
+		}
+		i := slices.IndexFunc(header, func(h string) bool {
+			key := columnKey(h)
+			return key == columnKey(f.name) || slices.ContainsFunc(f.aliases, func(a string) bool { return key == columnKey(a) })
+		})
+		// a column mapped explicitly isn't guessed for another field
+		if i >= 0 && !slices.Contains(mapped(index), i) {
+			index[f.name] = i
+		}
+	}
+	for _, required := range []string{"company", "position"} {
+		if _, ok := index[required]; !ok {
+			return nil, nil, fmt.Errorf("%w: no %s column, map one with %s=Column", ErrInvalid, required, required)
+		}
+	}
+
+	for name, i := range index {
+		result.Columns[name] = header[i]
+	}
+	for i, h := range header {
+		if !slices.Contains(mapped(index), i) && strings.TrimSpace(h) != "" {
+			result.Unmapped = append(result.Unmapped, h)
+		}
+	}
+	return index, result, nil
+}
+
+func mapped(index map[string]int) []int {
+	var columns []int
+	for _, i := range index {
+		columns = append(columns, i)
+	}
+	return columns
+}
+
+// readRow collects every bad cell of a row, not just the first, so one pass over the
+// errors is enough to fix the spreadsheet.
+func readRow(record, header []string, index map[string]int, row int) (types.Application, []types.ImportError) {
+	var app types.Application
+	var errs []types.ImportError
+	for _, f := range fields {
+		i, ok := index[f.name]
+		if !ok || i >= len(record) {
+			continue
+		}
+		value := strings.TrimSpace(record[i])
+		if value == "" {
+			continue
+		}
+		// undo the apostrophe export puts before text that looks like a formula
+		if len(value) > 1 && value[0] == '\'' && strings.ContainsAny(value[1:2], formulaStart) {
+			value = value[1:]
+		}
+		if err := f.set(&app, value); err != nil {
+			errs = append(errs, types.ImportError{Row: row, Column: header[i], Value: value, Message: err.Error()})
+		}
+	}
+	return app, errs
+}
+
+func blank(record []string) bool {
+	for _, v := range record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// statusAliases are what spreadsheets tend to call the pipeline stages.
+var statusAliases = map[string]types.ApplicationStatus{
+	"wishlist":     types.StatusSaved,
+	"to apply":     types.StatusSaved,
+	"not applied":  types.StatusSaved,
+	"sent":         types.StatusApplied,
+	"submitted":    types.StatusApplied,
+	"phone screen": types.StatusScreening,
+	"recruiter":    types.StatusScreening,
+	"interview":    types.StatusInterviewing,
+	"interviews":   types.StatusInterviewing,
+	"onsite":       types.StatusInterviewing,
+	"offered":      types.StatusOffer,
+	"rejection":    types.StatusRejected,
+	"declined":     types.StatusRejected,
+	"withdrew":     types.StatusWithdrawn,
+}
+
+func parseStatus(v string) (types.ApplicationStatus, error) {
+	if status, ok := statusAliases[strings.ToLower(strings.Join(strings.Fields(v), " "))]; ok {
+		return status, nil
+	}
+	status, err := applications.ParseStatus(v)
+	if err != nil {
+		return "", fmt.Errorf("unknown status, use one of %v", applications.Statuses())
+	}
+	return status, nil
+}
+
+// parseScore reads "7.5" or "7.5/10".
+func parseScore(v string) (float64, error) {
+	v = strings.TrimSpace(strings.TrimSuffix(strings.ReplaceAll(v, " ", ""), "/10"))
+	score, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, fmt.Errorf("score must be a number")
+	}
+	if score < 0 || score > 10 {
+		return 0, fmt.Errorf("score must be between 0 and 10")
+	}
+	return score, nil
+}
+
+// dateLayouts are tried in order. Slashed dates are read month first.
+var dateLayouts = []string{
+	time.DateOnly,
+	time.RFC3339,
+	time.DateTime,
+	"2006-01-02 15:04",
+	"2006-01-02T15:04:05",
+	"2006/01/02",
+	"1/2/2006",
+	"1/2/06",
+	"1/2/2006 15:04",
+	"1/2/2006 15:04:05",
+	"Jan 2, 2006",
+	"January 2, 2006",
+	"2 Jan 2006",
+	"2 January 2006",
+}
+
+func parseDate(v string) (time.Time, error) {
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, v); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("not a date, use YYYY-MM-DD or MM/DD/YYYY")
+}
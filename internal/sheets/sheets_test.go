@@ -0,0 +1,198 @@
+package sheets
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/p-shah256/tracker/internal/applications"
+	"github.com/p-shah256/tracker/internal/storage/storagetest"
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+func newTestService(t *testing.T) *applications.Service {
+	return applications.NewService(storagetest.Open(t))
+}
+
+func TestCSVValue(t *testing.T) {
+	tests := []struct {
+		in   cell
+		want string
+	}{
+		{text("Acme"), "Acme"},
+		{text(""), ""},
+		{text("=HYPERLINK(\"http://evil\",\"x\")"), "'=HYPERLINK(\"http://evil\",\"x\")"},
+		{text("+1 555 0100"), "'+1 555 0100"},
+		{text("-2 rounds left"), "'-2 rounds left"},
+		{text("@SUM(A1)"), "'@SUM(A1)"},
+		{text("\tcmd"), "'\tcmd"},
+		{text("\rcmd"), "'\rcmd"},
+		{text("a=b"), "a=b"},
+		{cell{kind: numberCell, number: -1.5}, "-1.5"},
+		{moment(ptr(time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)), dateCell), "2026-03-02"},
+	}
+	for _, tt := range tests {
+		if got := csvValue(tt.in); got != tt.want {
+			t.Errorf("csvValue(%q) = %q, want %q", tt.in.text, got, tt.want)
+		}
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func TestExportImportRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	deadline := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	apps := []types.Application{{
+		ID:          "a1",
+		UserID:      "ada",
+		Company:     "Acme, Inc.",
+		Position:    "Backend Engineer",
+		CompanySize: "small",
+		Status:      types.StatusApplied,
+		Score:       ptr(7.5),
+		Notes:       "=1+1 is not a formula here\nsecond line",
+		Deadline:    &deadline,
+		CreatedAt:   time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC),
+	}}
+	var buf bytes.Buffer
+	if err := Export(&buf, apps, FormatCSV); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(bytes.NewReader(buf.Bytes())).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[0][0] != "id" {
+		t.Fatalf("records = %q", records)
+	}
+
+	result, err := Import(ctx, newTestService(t), &buf, ImportOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Imported != 1 || len(result.Errors) != 0 {
+		t.Fatalf("result = %+v", result)
+	}
+	got := result.Applications[0]
+	if got.Notes != apps[0].Notes || got.Company != "Acme, Inc." || got.UserID != "ada" || *got.Score != 7.5 ||
+		got.Status != types.StatusApplied || !got.Deadline.Equal(deadline) || got.CompanySize != "small" {
+		t.Errorf("imported = %+v", got)
+	}
+}
+
+func TestImport(t *testing.T) {
+	ctx := context.Background()
+	in := "\ufeffEmployer,Job Title,Stage,Fit,Date Applied,Salary\n" +
+		"Acme,Engineer,Phone Screen,8/10,3/2/2026,lots\n" +
+		",,,,,\n" +
+		"Globex,,wishlist,11,someday,\n" +
+		"Initech,Analyst,,,,\n"
+	result, err := Import(ctx, newTestService(t), strings.NewReader(in), ImportOptions{UserID: "ada"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Rows != 3 || result.Imported != 2 {
+		t.Errorf("rows %d imported %d, want 3 and 2", result.Rows, result.Imported)
+	}
+	if result.Columns["company"] != "Employer" || result.Columns["created_at"] != "Date Applied" {
+		t.Errorf("columns = %v", result.Columns)
+	}
+	if strings.Join(result.Unmapped, ",") != "Salary" {
+		t.Errorf("unmapped = %v", result.Unmapped)
+	}
+	// the Globex row is wrong twice over, both are reported
+	if len(result.Errors) != 2 || result.Errors[0].Row != 4 || result.Errors[0].Column != "Fit" {
+		t.Errorf("errors = %+v", result.Errors)
+	}
+	acme := result.Applications[0]
+	if acme.Status != types.StatusScreening || *acme.Score != 8 || acme.UserID != "ada" || acme.CreatedAt.Day() != 2 {
+		t.Errorf("acme = %+v", acme)
+	}
+}
+
+func TestImportMappingErrors(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService(t)
+	tests := []struct {
+		name    string
+		in      string
+		mapping Mapping
+	}{
+		{"empty", "", nil},
+		{"no company column", "Name,Title\nAcme,Engineer\n", nil},
+		{"mapped column missing", "Company,Title\nAcme,Engineer\n", Mapping{"company": "Employer"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Import(ctx, svc, strings.NewReader(tt.in), ImportOptions{Mapping: tt.mapping})
+			if !errors.Is(err, ErrInvalid) || result != nil {
+				t.Errorf("Import() = %+v, %v, want ErrInvalid", result, err)
+			}
+		})
+	}
+	if _, err := ParseMapping([]string{"salary=Pay"}); !errors.Is(err, ErrInvalid) {
+		t.Errorf("unknown field error = %v", err)
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, errors.New("connection reset") }
+
+func TestImportStoppedPartway(t *testing.T) {
+	in := io.MultiReader(strings.NewReader("company,position\nAcme,Engineer\nGlobex,Engineer\n"), failingReader{})
+	result, err := Import(context.Background(), newTestService(t), in, ImportOptions{})
+	if err == nil {
+		t.Fatal("Import succeeded")
+	}
+	if result == nil || result.Imported != 2 || len(result.Applications) != 2 {
+		t.Errorf("result = %+v, want the two rows created before the failure", result)
+	}
+}
+
+func TestXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	apps := []types.Application{{ID: "a1", Company: "=cmd|' /C calc'!A0", Position: "Engineer <3", Status: types.StatusSaved}}
+	if err := Export(&buf, apps, FormatXLSX); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sheet string
+	for _, f := range zr.File {
+		if f.Name == "xl/worksheets/sheet1.xml" {
+			rc, _ := f.Open()
+			b, _ := io.ReadAll(rc)
+			rc.Close()
+			sheet = string(b)
+		}
+	}
+	for _, want := range []string{
+		`<c r="B2" t="inlineStr"><is><t xml:space="preserve">=cmd|&#39; /C calc&#39;!A0</t></is></c>`,
+		`Engineer &lt;3`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet is missing %q:\n%s", want, sheet)
+		}
+	}
+	if strings.Contains(sheet, "<f>") {
+		t.Error("sheet has a formula")
+	}
+}
+
+func TestCellRef(t *testing.T) {
+	for col, want := range map[int]string{0: "A1", 25: "Z1", 26: "AA1", 701: "ZZ1", 702: "AAA1"} {
+		if got := cellRef(col, 1); got != want {
+			t.Errorf("cellRef(%d, 1) = %s, want %s", col, got, want)
+		}
+	}
+}
@@ -0,0 +1,311 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+const applicationColumns = `id, user_id, company, position, level, company_size, url, job_id, resume_id, resume_version_id, score_run_id, score, status, notes,
+	deadline, created_at, updated_at`
+
+func (s *SQLite) CreateApplication(ctx context.Context, app *types.Application) error {
+	if app.ID == "" {
+		app.ID = uuid.New().String()
+	}
+	now := time.Now().UTC()
+	if app.CreatedAt.IsZero() {
+		app.CreatedAt = now
+	}
+	app.CreatedAt = app.CreatedAt.UTC()
+	app.UpdatedAt = now
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO applications (`+applicationColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		app.ID, app.UserID, app.Company, app.Position, app.Level, app.CompanySize, app.URL, nullable(app.JobID), nullable(app.ResumeID), nullable(app.ResumeVersionID),
+		nullable(app.ScoreRunID), app.Score, app.Status, app.Notes, nullableTime(app.Deadline), app.CreatedAt, app.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save application: %w", err)
+	}
+
+	// the first status is part of the history too, so every stage has a start time
+	created := types.StatusChange{To: app.Status, At: app.CreatedAt}
+	if err := insertEvent(ctx, tx, app.ID, created); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to save application: %w", err)
+	}
+	app.History = []types.StatusChange{created}
+	return nil
+}
+
+func (s *SQLite) GetApplication(ctx context.Context, id string) (*types.Application, error) {
+	app, err := scanApplication(s.db.QueryRowContext(ctx, `SELECT `+applicationColumns+` FROM applications WHERE id = ?`, id))
+	if err != nil {
+		return nil, notFound(err, "application", id)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT from_status, to_status, note, at FROM application_events
+		WHERE application_id = ? ORDER BY at, id`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load application history: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var change types.StatusChange
+		if err := rows.Scan(&change.From, &change.To, &change.Note, &change.At); err != nil {
+			return nil, fmt.Errorf("failed to load application history: %w", err)
+		}
+		app.History = append(app.History, change)
+	}
+	return app, rows.Err()
+}
+
+func (s *SQLite) ListApplications(ctx context.Context, filter ApplicationFilter) ([]types.Application, error) {
+	var where []string
+	var args []any
+	if filter.UserID != "" {
+		where = append(where, "user_id = ?")
+		args = append(args, filter.UserID)
+	}
+	if len(filter.Statuses) > 0 {
+		where = append(where, "status IN (?"+strings.Repeat(", ?", len(filter.Statuses)-1)+")")
+		for _, status := range filter.Statuses {
+			args = append(args, status)
+		}
+	}
+	if filter.Company != "" {
+		where = append(where, "company LIKE ? ESCAPE '\\'")
+		args = append(args, "%"+likeEscaper.Replace(filter.Company)+"%")
+	}
+	if filter.MinScore != nil {
+		where = append(where, "score >= ?")
+		args = append(args, *filter.MinScore)
+	}
+	if !filter.Since.IsZero() {
+		where = append(where, "created_at >= ?")
+		args = append(args, filter.Since.UTC())
+	}
+	if !filter.Until.IsZero() {
+		where = append(where, "created_at < ?")
+		args = append(args, filter.Until.UTC())
+	}
+
+	query := `SELECT ` + applicationColumns + ` FROM applications`
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY updated_at DESC, id"
+	if filter.Limit > 0 {
+		query += " LIMIT ? OFFSET ?"
+		args = append(args, filter.Limit, filter.Offset)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+	defer rows.Close()
+
+	apps := []types.Application{}
+	for rows.Next() {
+		app, err := scanApplication(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list applications: %w", err)
+		}
+		apps = append(apps, *app)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+	if filter.WithHistory && len(apps) > 0 {
+		if err := s.loadHistories(ctx, apps); err != nil {
+			return nil, err
+		}
+	}
+	return apps, nil
+}
+
+// loadHistories fills in the status history of every application in one query.
+func (s *SQLite) loadHistories(ctx context.Context, apps []types.Application) error {
+	byID := make(map[string]*types.Application, len(apps))
+	args := make([]any, len(apps))
+	for i := range apps {
+		byID[apps[i].ID] = &apps[i]
+		args[i] = apps[i].ID
+	}
+	rows, err := s.db.QueryContext(ctx, `SELECT application_id, from_status, to_status, note, at FROM application_events
+		WHERE application_id IN (?`+strings.Repeat(", ?", len(apps)-1)+`) ORDER BY at, id`, args...)
+	if err != nil {
+		return fmt.Errorf("failed to load application history: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id string
+		var change types.StatusChange
+		if err := rows.Scan(&id, &change.From, &change.To, &change.Note, &change.At); err != nil {
+			return fmt.Errorf("failed to load application history: %w", err)
+		}
+		app := byID[id]
+		app.History = append(app.History, change)
+	}
+	return rows.Err()
+}
+
+func (s *SQLite) UpdateApplication(ctx context.Context, app *types.Application) error {
+	app.UpdatedAt = time.Now().UTC()
+	res, err := s.db.ExecContext(ctx, `UPDATE applications SET user_id = ?, company = ?, position = ?, level = ?, company_size = ?, url = ?, job_id = ?,
+		resume_id = ?, resume_version_id = ?, score_run_id = ?, score = ?, notes = ?, deadline = ?, updated_at = ? WHERE id = ?`,
+		app.UserID, app.Company, app.Position, app.Level, app.CompanySize, app.URL, nullable(app.JobID), nullable(app.ResumeID), nullable(app.ResumeVersionID),
+		nullable(app.ScoreRunID), app.Score, app.Notes, nullableTime(app.Deadline), app.UpdatedAt, app.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update application: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%w: application %s", ErrNotFound, app.ID)
+	}
+	return nil
+}
+
+func (s *SQLite) SetStatus(ctx context.Context, id string, from types.ApplicationStatus, change types.StatusChange) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `UPDATE applications SET status = ?, updated_at = ? WHERE id = ? AND status = ?`,
+		change.To, time.Now().UTC(), id, from)
+	if err != nil {
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		if _, err := currentStatus(ctx, tx, id); err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: application %s is no longer %s", ErrConflict, id, from)
+	}
+
+	change.From = from
+	if err := insertEvent(ctx, tx, id, change); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+	return nil
+}
+
+func currentStatus(ctx context.Context, tx *sql.Tx, id string) (types.ApplicationStatus, error) {
+	var status types.ApplicationStatus
+	if err := tx.QueryRowContext(ctx, `SELECT status FROM applications WHERE id = ?`, id).Scan(&status); err != nil {
+		return "", notFound(err, "application", id)
+	}
+	return status, nil
+}
+
+func (s *SQLite) DeleteApplication(ctx context.Context, id string) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM applications WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete application: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%w: application %s", ErrNotFound, id)
+	}
+	return nil
+}
+
+func (s *SQLite) MergeApplications(ctx context.Context, app *types.Application, duplicateID string) error {
+	app.UpdatedAt = time.Now().UTC()
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `UPDATE applications SET user_id = ?, company = ?, position = ?, level = ?, company_size = ?, url = ?, job_id = ?,
+		resume_id = ?, resume_version_id = ?, score_run_id = ?, score = ?, status = ?, notes = ?, deadline = ?, created_at = ?,
+		updated_at = ? WHERE id = ?`,
+		app.UserID, app.Company, app.Position, app.Level, app.CompanySize, app.URL, nullable(app.JobID), nullable(app.ResumeID), nullable(app.ResumeVersionID),
+		nullable(app.ScoreRunID), app.Score, app.Status, app.Notes, nullableTime(app.Deadline), app.CreatedAt.UTC(), app.UpdatedAt, app.ID)
+	if err != nil {
+		return fmt.Errorf("failed to merge applications: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%w: application %s", ErrNotFound, app.ID)
+	}
+
+	// the duplicate's creation event is dropped, the kept application already has one.
+	// its interviews, reminders, contacts and interactions move over with the rest of its history
+	if _, err := tx.ExecContext(ctx, `UPDATE application_events SET application_id = ? WHERE application_id = ? AND from_status != ''`,
+		app.ID, duplicateID); err != nil {
+		return fmt.Errorf("failed to merge application history: %w", err)
+	}
+	for _, table := range []string{"interviews", "reminders", "interactions"} {
+		if _, err := tx.ExecContext(ctx, `UPDATE `+table+` SET application_id = ? WHERE application_id = ?`, app.ID, duplicateID); err != nil {
+			return fmt.Errorf("failed to merge %s: %w", table, err)
+		}
+	}
+	// contacts linked to both stay linked once, the duplicate's link goes with it
+	if _, err := tx.ExecContext(ctx, `UPDATE OR IGNORE application_contacts SET application_id = ? WHERE application_id = ?`, app.ID, duplicateID); err != nil {
+		return fmt.Errorf("failed to merge contacts: %w", err)
+	}
+	res, err = tx.ExecContext(ctx, `DELETE FROM applications WHERE id = ?`, duplicateID)
+	if err != nil {
+		return fmt.Errorf("failed to merge applications: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%w: application %s", ErrNotFound, duplicateID)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to merge applications: %w", err)
+	}
+	return nil
+}
+
+func insertEvent(ctx context.Context, tx *sql.Tx, id string, change types.StatusChange) error {
+	_, err := tx.ExecContext(ctx, `INSERT INTO application_events (application_id, from_status, to_status, note, at) VALUES (?, ?, ?, ?, ?)`,
+		id, change.From, change.To, change.Note, change.At.UTC())
+	if err != nil {
+		return fmt.Errorf("failed to record status change: %w", err)
+	}
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanApplication(row scanner) (*types.Application, error) {
+	var app types.Application
+	var jobID, resumeID, versionID, scoreRunID sql.NullString
+	var score sql.NullFloat64
+	var deadline sql.NullTime
+	err := row.Scan(&app.ID, &app.UserID, &app.Company, &app.Position, &app.Level, &app.CompanySize, &app.URL, &jobID, &resumeID, &versionID, &scoreRunID,
+		&score, &app.Status, &app.Notes, &deadline, &app.CreatedAt, &app.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if deadline.Valid {
+		app.Deadline = &deadline.Time
+	}
+	app.JobID, app.ResumeID, app.ResumeVersionID, app.ScoreRunID = jobID.String, resumeID.String, versionID.String, scoreRunID.String
+	if score.Valid {
+		app.Score = &score.Float64
+	}
+	return &app, nil
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+func (s *SQLite) SetCalendarToken(ctx context.Context, userID, token string) error {
+	_, err := s.db.ExecContext(ctx, `INSERT INTO calendar_tokens (user_id, token_hash, created_at) VALUES (?, ?, ?)
+		ON CONFLICT (user_id) DO UPDATE SET token_hash = excluded.token_hash, created_at = excluded.created_at`,
+		userID, tokenHash(token), time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to save calendar token: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLite) CalendarUser(ctx context.Context, token string) (string, error) {
+	var userID string
+	err := s.db.QueryRowContext(ctx, `SELECT user_id FROM calendar_tokens WHERE token_hash = ?`, tokenHash(token)).Scan(&userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		// the token stays out of the error, it ends up in responses
+		return "", fmt.Errorf("%w: calendar feed", ErrNotFound)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to look up calendar token: %w", err)
+	}
+	return userID, nil
+}
+
+func tokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
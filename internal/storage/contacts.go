@@ -0,0 +1,303 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+const contactColumns = `c.id, c.user_id, c.name, c.role, c.company, c.title, c.email, c.phone, c.linkedin, c.notes, c.created_at, c.updated_at`
+
+func (s *SQLite) CreateContact(ctx context.Context, c *types.Contact) error {
+	if c.ID == "" {
+		c.ID = uuid.New().String()
+	}
+	now := time.Now().UTC()
+	c.CreatedAt, c.UpdatedAt = now, now
+
+	_, err := s.db.ExecContext(ctx, `INSERT INTO contacts (id, user_id, name, role, company, title, email, phone, linkedin, notes, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		c.ID, c.UserID, c.Name, c.Role, c.Company, c.Title, c.Email, c.Phone, c.LinkedIn, c.Notes, c.CreatedAt, c.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save contact: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLite) GetContact(ctx context.Context, id string) (*types.Contact, error) {
+	c, err := scanContact(s.db.QueryRowContext(ctx, `SELECT `+contactColumns+` FROM contacts c WHERE c.id = ?`, id))
+	if err != nil {
+		return nil, notFound(err, "contact", id)
+	}
+	contacts := []types.Contact{*c}
+	if err := s.loadContactActivity(ctx, contacts); err != nil {
+		return nil, err
+	}
+	return &contacts[0], nil
+}
+
+func (s *SQLite) ListContacts(ctx context.Context, filter ContactFilter) ([]types.Contact, error) {
+	var where []string
+	var args []any
+	if filter.UserID != "" {
+		where = append(where, "c.user_id = ?")
+		args = append(args, filter.UserID)
+	}
+	if filter.Company != "" {
+		where = append(where, "c.company LIKE ? ESCAPE '\\'")
+		args = append(args, "%"+likeEscaper.Replace(filter.Company)+"%")
+	}
+	if filter.Role != "" {
+		where = append(where, "c.role = ?")
+		args = append(args, filter.Role)
+	}
+	if filter.ApplicationID != "" {
+		where = append(where, "c.id IN (SELECT contact_id FROM application_contacts WHERE application_id = ?)")
+		args = append(args, filter.ApplicationID)
+	}
+
+	query := `SELECT ` + contactColumns + ` FROM contacts c`
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY c.name COLLATE NOCASE, c.id"
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contacts: %w", err)
+	}
+	defer rows.Close()
+
+	contacts := []types.Contact{}
+	for rows.Next() {
+		c, err := scanContact(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list contacts: %w", err)
+		}
+		contacts = append(contacts, *c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list contacts: %w", err)
+	}
+	if len(contacts) == 0 {
+		return contacts, nil
+	}
+	if err := s.loadContactActivity(ctx, contacts); err != nil {
+		return nil, err
+	}
+
+	if !filter.ContactedBefore.IsZero() {
+		stale := contacts[:0]
+		for _, c := range contacts {
+			if c.LastContactedAt == nil || c.LastContactedAt.Before(filter.ContactedBefore) {
+				stale = append(stale, c)
+			}
+		}
+		contacts = stale
+	}
+	return contacts, nil
+}
+
+// loadContactActivity fills in the interaction count, last contact date and linked
+// applications of each contact.
+func (s *SQLite) loadContactActivity(ctx context.Context, contacts []types.Contact) error {
+	byID := make(map[string]*types.Contact, len(contacts))
+	args := make([]any, len(contacts))
+	for i := range contacts {
+		byID[contacts[i].ID] = &contacts[i]
+		args[i] = contacts[i].ID
+	}
+	in := `(?` + strings.Repeat(", ?", len(contacts)-1) + `)`
+
+	rows, err := s.db.QueryContext(ctx, `SELECT contact_id, at FROM interactions WHERE contact_id IN `+in, args...)
+	if err != nil {
+		return fmt.Errorf("failed to load contact interactions: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id string
+		var at time.Time
+		if err := rows.Scan(&id, &at); err != nil {
+			return fmt.Errorf("failed to load contact interactions: %w", err)
+		}
+		c := byID[id]
+		c.Interactions++
+		if c.LastContactedAt == nil || at.After(*c.LastContactedAt) {
+			c.LastContactedAt = &at
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to load contact interactions: %w", err)
+	}
+
+	links, err := s.db.QueryContext(ctx, `SELECT contact_id, application_id FROM application_contacts
+		WHERE contact_id IN `+in+` ORDER BY created_at, application_id`, args...)
+	if err != nil {
+		return fmt.Errorf("failed to load contact applications: %w", err)
+	}
+	defer links.Close()
+	for links.Next() {
+		var id, applicationID string
+		if err := links.Scan(&id, &applicationID); err != nil {
+			return fmt.Errorf("failed to load contact applications: %w", err)
+		}
+		byID[id].ApplicationIDs = append(byID[id].ApplicationIDs, applicationID)
+	}
+	return links.Err()
+}
+
+// UpdateContact saves the contact's details, its links and interactions are left alone.
+func (s *SQLite) UpdateContact(ctx context.Context, c *types.Contact) error {
+	c.UpdatedAt = time.Now().UTC()
+	res, err := s.db.ExecContext(ctx, `UPDATE contacts SET user_id = ?, name = ?, role = ?, company = ?, title = ?, email = ?, phone = ?,
+		linkedin = ?, notes = ?, updated_at = ? WHERE id = ?`,
+		c.UserID, c.Name, c.Role, c.Company, c.Title, c.Email, c.Phone, c.LinkedIn, c.Notes, c.UpdatedAt, c.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update contact: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%w: contact %s", ErrNotFound, c.ID)
+	}
+	return nil
+}
+
+func (s *SQLite) DeleteContact(ctx context.Context, id string) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM contacts WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete contact: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%w: contact %s", ErrNotFound, id)
+	}
+	return nil
+}
+
+func (s *SQLite) LinkContact(ctx context.Context, applicationID, contactID string) error {
+	if err := s.exists(ctx, "applications", "application", applicationID); err != nil {
+		return err
+	}
+	if err := s.exists(ctx, "contacts", "contact", contactID); err != nil {
+		return err
+	}
+	_, err := s.db.ExecContext(ctx, `INSERT OR IGNORE INTO application_contacts (application_id, contact_id, created_at) VALUES (?, ?, ?)`,
+		applicationID, contactID, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to link contact: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLite) UnlinkContact(ctx context.Context, applicationID, contactID string) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM application_contacts WHERE application_id = ? AND contact_id = ?`, applicationID, contactID)
+	if err != nil {
+		return fmt.Errorf("failed to unlink contact: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%w: contact %s on application %s", ErrNotFound, contactID, applicationID)
+	}
+	return nil
+}
+
+// exists checks a row is there before it's linked to, so a bad ID is a not found
+// rather than a foreign key failure.
+func (s *SQLite) exists(ctx context.Context, table, what, id string) error {
+	var one int
+	err := s.db.QueryRowContext(ctx, `SELECT 1 FROM `+table+` WHERE id = ?`, id).Scan(&one)
+	if err != nil {
+		return notFound(err, what, id)
+	}
+	return nil
+}
+
+func (s *SQLite) CreateInteraction(ctx context.Context, i *types.Interaction) error {
+	if i.ID == "" {
+		i.ID = uuid.New().String()
+	}
+	i.CreatedAt = time.Now().UTC()
+	if i.At.IsZero() {
+		i.At = i.CreatedAt
+	}
+	i.At = i.At.UTC()
+
+	err := s.db.QueryRowContext(ctx, `SELECT name FROM contacts WHERE id = ?`, i.ContactID).Scan(&i.ContactName)
+	if err != nil {
+		return notFound(err, "contact", i.ContactID)
+	}
+	if i.ApplicationID != "" {
+		if err := s.exists(ctx, "applications", "application", i.ApplicationID); err != nil {
+			return err
+		}
+	}
+	_, err = s.db.ExecContext(ctx, `INSERT INTO interactions (id, contact_id, application_id, kind, summary, at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		i.ID, i.ContactID, nullable(i.ApplicationID), i.Kind, i.Summary, i.At, i.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save interaction: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLite) ListInteractions(ctx context.Context, filter InteractionFilter) ([]types.Interaction, error) {
+	var where []string
+	var args []any
+	if filter.ContactID != "" {
+		where = append(where, "i.contact_id = ?")
+		args = append(args, filter.ContactID)
+	}
+	if filter.ApplicationID != "" {
+		where = append(where, "i.application_id = ?")
+		args = append(args, filter.ApplicationID)
+	}
+
+	query := `SELECT i.id, i.contact_id, i.application_id, i.kind, i.summary, i.at, i.created_at, c.name
+		FROM interactions i JOIN contacts c ON c.id = i.contact_id`
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY i.at DESC, i.id"
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list interactions: %w", err)
+	}
+	defer rows.Close()
+
+	interactions := []types.Interaction{}
+	for rows.Next() {
+		var i types.Interaction
+		var applicationID sql.NullString
+		if err := rows.Scan(&i.ID, &i.ContactID, &applicationID, &i.Kind, &i.Summary, &i.At, &i.CreatedAt, &i.ContactName); err != nil {
+			return nil, fmt.Errorf("failed to list interactions: %w", err)
+		}
+		i.ApplicationID = applicationID.String
+		interactions = append(interactions, i)
+	}
+	return interactions, rows.Err()
+}
+
+func (s *SQLite) DeleteInteraction(ctx context.Context, id string) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM interactions WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete interaction: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%w: interaction %s", ErrNotFound, id)
+	}
+	return nil
+}
+
+func scanContact(row scanner) (*types.Contact, error) {
+	var c types.Contact
+	err := row.Scan(&c.ID, &c.UserID, &c.Name, &c.Role, &c.Company, &c.Title, &c.Email, &c.Phone, &c.LinkedIn, &c.Notes,
+		&c.CreatedAt, &c.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+const interviewColumns = `i.id, i.application_id, i.title, i.starts_at, i.ends_at, i.location, i.notes, i.created_at, a.company, a.position`
+
+const interviewFrom = ` FROM interviews i JOIN applications a ON a.id = i.application_id`
+
+func (s *SQLite) CreateInterview(ctx context.Context, i *types.Interview) error {
+	if i.ID == "" {
+		i.ID = uuid.New().String()
+	}
+	if i.CreatedAt.IsZero() {
+		i.CreatedAt = time.Now()
+	}
+	i.CreatedAt, i.StartsAt, i.EndsAt = i.CreatedAt.UTC(), i.StartsAt.UTC(), i.EndsAt.UTC()
+
+	err := s.db.QueryRowContext(ctx, `SELECT company, position FROM applications WHERE id = ?`, i.ApplicationID).Scan(&i.Company, &i.Position)
+	if err != nil {
+		return notFound(err, "application", i.ApplicationID)
+	}
+	_, err = s.db.ExecContext(ctx, `INSERT INTO interviews (id, application_id, title, starts_at, ends_at, location, notes, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		i.ID, i.ApplicationID, i.Title, i.StartsAt, i.EndsAt, i.Location, i.Notes, i.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save interview: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLite) GetInterview(ctx context.Context, id string) (*types.Interview, error) {
+	i, err := scanInterview(s.db.QueryRowContext(ctx, `SELECT `+interviewColumns+interviewFrom+` WHERE i.id = ?`, id))
+	if err != nil {
+		return nil, notFound(err, "interview", id)
+	}
+	return i, nil
+}
+
+func (s *SQLite) ListInterviews(ctx context.Context, filter InterviewFilter) ([]types.Interview, error) {
+	var where []string
+	var args []any
+	if filter.UserID != "" {
+		where = append(where, "a.user_id = ?")
+		args = append(args, filter.UserID)
+	}
+	if filter.ApplicationID != "" {
+		where = append(where, "i.application_id = ?")
+		args = append(args, filter.ApplicationID)
+	}
+	if !filter.Since.IsZero() {
+		where = append(where, "i.ends_at >= ?")
+		args = append(args, filter.Since.UTC())
+	}
+
+	query := `SELECT ` + interviewColumns + interviewFrom
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY i.starts_at, i.id"
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list interviews: %w", err)
+	}
+	defer rows.Close()
+
+	interviews := []types.Interview{}
+	for rows.Next() {
+		i, err := scanInterview(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list interviews: %w", err)
+		}
+		interviews = append(interviews, *i)
+	}
+	return interviews, rows.Err()
+}
+
+func (s *SQLite) UpdateInterview(ctx context.Context, i *types.Interview) error {
+	i.StartsAt, i.EndsAt = i.StartsAt.UTC(), i.EndsAt.UTC()
+	res, err := s.db.ExecContext(ctx, `UPDATE interviews SET title = ?, starts_at = ?, ends_at = ?, location = ?, notes = ? WHERE id = ?`,
+		i.Title, i.StartsAt, i.EndsAt, i.Location, i.Notes, i.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update interview: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%w: interview %s", ErrNotFound, i.ID)
+	}
+	return nil
+}
+
+func (s *SQLite) DeleteInterview(ctx context.Context, id string) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM interviews WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete interview: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%w: interview %s", ErrNotFound, id)
+	}
+	return nil
+}
+
+func scanInterview(row scanner) (*types.Interview, error) {
+	var i types.Interview
+	err := row.Scan(&i.ID, &i.ApplicationID, &i.Title, &i.StartsAt, &i.EndsAt, &i.Location, &i.Notes, &i.CreatedAt, &i.Company, &i.Position)
+	if err != nil {
+		return nil, err
+	}
+	return &i, nil
+}
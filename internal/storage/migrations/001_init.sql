@@ -0,0 +1,38 @@
+CREATE TABLE jobs (
+    request_id  TEXT PRIMARY KEY,
+    url         TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL,
+    company     TEXT NOT NULL DEFAULT '',
+    position    TEXT NOT NULL DEFAULT '',
+    skills      TEXT,
+    created_at  DATETIME NOT NULL
+);
+
+CREATE TABLE resumes (
+    id         TEXT PRIMARY KEY,
+    name       TEXT NOT NULL,
+    data       TEXT NOT NULL,
+    chosen     TEXT,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);
+
+CREATE TABLE score_runs (
+    request_id    TEXT PRIMARY KEY,
+    job_id        TEXT REFERENCES jobs(request_id) ON DELETE SET NULL,
+    resume        TEXT NOT NULL,
+    overall_score REAL NOT NULL,
+    result        TEXT NOT NULL,
+    created_at    DATETIME NOT NULL
+);
+
+CREATE INDEX score_runs_job_id ON score_runs(job_id);
+
+CREATE TABLE transforms (
+    request_id TEXT PRIMARY KEY,
+    section    TEXT NOT NULL,
+    mode       TEXT NOT NULL,
+    request    TEXT NOT NULL,
+    result     TEXT NOT NULL,
+    created_at DATETIME NOT NULL
+);
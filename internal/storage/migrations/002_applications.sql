@@ -0,0 +1,28 @@
+CREATE TABLE applications (
+    id           TEXT PRIMARY KEY,
+    company      TEXT NOT NULL,
+    position     TEXT NOT NULL,
+    level        TEXT NOT NULL DEFAULT '',
+    url          TEXT NOT NULL DEFAULT '',
+    job_id       TEXT REFERENCES jobs(request_id) ON DELETE SET NULL,
+    resume_id    TEXT REFERENCES resumes(id) ON DELETE SET NULL,
+    score_run_id TEXT REFERENCES score_runs(request_id) ON DELETE SET NULL,
+    score        REAL,
+    status       TEXT NOT NULL,
+    notes        TEXT NOT NULL DEFAULT '',
+    created_at   DATETIME NOT NULL,
+    updated_at   DATETIME NOT NULL
+);
+
+CREATE INDEX applications_status ON applications(status);
+
+CREATE TABLE application_events (
+    id             INTEGER PRIMARY KEY AUTOINCREMENT,
+    application_id TEXT NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    from_status    TEXT NOT NULL DEFAULT '',
+    to_status      TEXT NOT NULL,
+    note           TEXT NOT NULL DEFAULT '',
+    at             DATETIME NOT NULL
+);
+
+CREATE INDEX application_events_application_id ON application_events(application_id, at);
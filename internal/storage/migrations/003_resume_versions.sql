@@ -0,0 +1,20 @@
+CREATE TABLE resume_versions (
+    id         TEXT PRIMARY KEY,
+    resume_id  TEXT NOT NULL REFERENCES resumes(id) ON DELETE CASCADE,
+    parent_id  TEXT REFERENCES resume_versions(id) ON DELETE SET NULL,
+    number     INTEGER NOT NULL,
+    note       TEXT NOT NULL DEFAULT '',
+    data       TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    UNIQUE (resume_id, number)
+);
+
+-- every resume saved before versions existed becomes its own version 1
+INSERT INTO resume_versions (id, resume_id, parent_id, number, note, data, created_at)
+SELECT lower(hex(randomblob(16))), id, NULL, 1, 'initial', data, created_at FROM resumes;
+
+ALTER TABLE applications ADD COLUMN resume_version_id TEXT REFERENCES resume_versions(id) ON DELETE SET NULL;
+ALTER TABLE score_runs ADD COLUMN resume_version_id TEXT REFERENCES resume_versions(id) ON DELETE SET NULL;
+
+CREATE INDEX applications_resume_version_id ON applications(resume_version_id);
+CREATE INDEX score_runs_resume_version_id ON score_runs(resume_version_id);
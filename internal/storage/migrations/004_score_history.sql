@@ -0,0 +1,9 @@
+ALTER TABLE score_runs ADD COLUMN model TEXT NOT NULL DEFAULT '';
+ALTER TABLE score_runs ADD COLUMN prompt_version TEXT NOT NULL DEFAULT '';
+
+-- jobs scored from the same description share a hash, so their runs form one history.
+-- rows from before this migration are hashed on startup
+ALTER TABLE jobs ADD COLUMN description_hash TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX jobs_description_hash ON jobs(description_hash);
+CREATE INDEX score_runs_created_at ON score_runs(created_at);
@@ -0,0 +1,14 @@
+CREATE TABLE reminders (
+    id             TEXT PRIMARY KEY,
+    application_id TEXT NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    kind           TEXT NOT NULL,
+    message        TEXT NOT NULL DEFAULT '',
+    due_at         DATETIME NOT NULL,
+    fired_at       DATETIME,
+    attempts       INTEGER NOT NULL DEFAULT 0,
+    last_error     TEXT NOT NULL DEFAULT '',
+    created_at     DATETIME NOT NULL
+);
+
+CREATE INDEX reminders_pending ON reminders(fired_at, due_at);
+CREATE INDEX reminders_application_id ON reminders(application_id);
@@ -0,0 +1,20 @@
+ALTER TABLE applications ADD COLUMN user_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE applications ADD COLUMN deadline DATETIME;
+
+CREATE INDEX applications_user_id ON applications(user_id);
+
+CREATE TABLE interviews (
+    id             TEXT PRIMARY KEY,
+    application_id TEXT NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    title          TEXT NOT NULL,
+    starts_at      DATETIME NOT NULL,
+    ends_at        DATETIME NOT NULL,
+    location       TEXT NOT NULL DEFAULT '',
+    notes          TEXT NOT NULL DEFAULT '',
+    created_at     DATETIME NOT NULL
+);
+
+CREATE INDEX interviews_application_id ON interviews(application_id, starts_at);
+
+-- prep reminders go with their interview
+ALTER TABLE reminders ADD COLUMN interview_id TEXT REFERENCES interviews(id) ON DELETE CASCADE;
@@ -0,0 +1 @@
+ALTER TABLE applications ADD COLUMN company_size TEXT NOT NULL DEFAULT '';
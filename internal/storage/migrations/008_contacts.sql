@@ -0,0 +1,39 @@
+CREATE TABLE contacts (
+    id         TEXT PRIMARY KEY,
+    user_id    TEXT NOT NULL DEFAULT '',
+    name       TEXT NOT NULL,
+    role       TEXT NOT NULL,
+    company    TEXT NOT NULL DEFAULT '',
+    title      TEXT NOT NULL DEFAULT '',
+    email      TEXT NOT NULL DEFAULT '',
+    phone      TEXT NOT NULL DEFAULT '',
+    linkedin   TEXT NOT NULL DEFAULT '',
+    notes      TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);
+
+CREATE INDEX contacts_user_company ON contacts(user_id, company);
+
+CREATE TABLE application_contacts (
+    application_id TEXT NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+    contact_id     TEXT NOT NULL REFERENCES contacts(id) ON DELETE CASCADE,
+    created_at     DATETIME NOT NULL,
+    PRIMARY KEY (application_id, contact_id)
+);
+
+CREATE INDEX application_contacts_contact_id ON application_contacts(contact_id);
+
+-- an interaction outlives the application it was about, it's still part of the contact's log
+CREATE TABLE interactions (
+    id             TEXT PRIMARY KEY,
+    contact_id     TEXT NOT NULL REFERENCES contacts(id) ON DELETE CASCADE,
+    application_id TEXT REFERENCES applications(id) ON DELETE SET NULL,
+    kind           TEXT NOT NULL,
+    summary        TEXT NOT NULL,
+    at             DATETIME NOT NULL,
+    created_at     DATETIME NOT NULL
+);
+
+CREATE INDEX interactions_contact_id ON interactions(contact_id, at);
+CREATE INDEX interactions_application_id ON interactions(application_id, at);
@@ -0,0 +1,2 @@
+-- a failed delivery is retried at next_attempt_at, due_at stays when the reminder was due
+ALTER TABLE reminders ADD COLUMN next_attempt_at DATETIME;
@@ -0,0 +1,6 @@
+-- one calendar feed token per user, only its hash is kept
+CREATE TABLE calendar_tokens (
+    user_id    TEXT PRIMARY KEY,
+    token_hash TEXT NOT NULL UNIQUE,
+    created_at DATETIME NOT NULL
+);
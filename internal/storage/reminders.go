@@ -0,0 +1,150 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+const reminderColumns = `r.id, r.application_id, r.interview_id, r.kind, r.message, r.due_at, r.fired_at, r.next_attempt_at, r.attempts, r.last_error, r.created_at,
+	a.company, a.position`
+
+func (s *SQLite) CreateReminder(ctx context.Context, r *types.Reminder) error {
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+	if r.CreatedAt.IsZero() {
+		r.CreatedAt = time.Now()
+	}
+	r.CreatedAt, r.DueAt = r.CreatedAt.UTC(), r.DueAt.UTC()
+
+	var company, position string
+	err := s.db.QueryRowContext(ctx, `SELECT company, position FROM applications WHERE id = ?`, r.ApplicationID).Scan(&company, &position)
+	if err != nil {
+		return notFound(err, "application", r.ApplicationID)
+	}
+	_, err = s.db.ExecContext(ctx, `INSERT INTO reminders (id, application_id, interview_id, kind, message, due_at, next_attempt_at,
+			attempts, last_error, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		r.ID, r.ApplicationID, nullable(r.InterviewID), r.Kind, r.Message, r.DueAt, nullableTime(r.NextAttemptAt),
+		r.Attempts, r.LastError, r.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save reminder: %w", err)
+	}
+	r.Company, r.Position = company, position
+	return nil
+}
+
+func (s *SQLite) ListReminders(ctx context.Context, filter ReminderFilter) ([]types.Reminder, error) {
+	var where []string
+	var args []any
+	if filter.ApplicationID != "" {
+		where = append(where, "r.application_id = ?")
+		args = append(args, filter.ApplicationID)
+	}
+	if filter.UserID != "" {
+		where = append(where, "a.user_id = ?")
+		args = append(args, filter.UserID)
+	}
+	if filter.Pending {
+		where = append(where, "r.fired_at IS NULL")
+	}
+	if !filter.DueBefore.IsZero() {
+		where = append(where, "r.due_at <= ?")
+		args = append(args, filter.DueBefore.UTC())
+	}
+	if !filter.AttemptBefore.IsZero() {
+		where = append(where, "COALESCE(r.next_attempt_at, r.due_at) <= ?")
+		args = append(args, filter.AttemptBefore.UTC())
+	}
+
+	query := `SELECT ` + reminderColumns + ` FROM reminders r JOIN applications a ON a.id = r.application_id`
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY r.due_at, r.id"
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reminders: %w", err)
+	}
+	defer rows.Close()
+
+	reminders := []types.Reminder{}
+	for rows.Next() {
+		var r types.Reminder
+		var interviewID sql.NullString
+		var firedAt, nextAttemptAt sql.NullTime
+		err := rows.Scan(&r.ID, &r.ApplicationID, &interviewID, &r.Kind, &r.Message, &r.DueAt, &firedAt, &nextAttemptAt, &r.Attempts, &r.LastError, &r.CreatedAt,
+			&r.Company, &r.Position)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list reminders: %w", err)
+		}
+		r.InterviewID = interviewID.String
+		if firedAt.Valid {
+			r.FiredAt = &firedAt.Time
+		}
+		if nextAttemptAt.Valid {
+			r.NextAttemptAt = &nextAttemptAt.Time
+		}
+		reminders = append(reminders, r)
+	}
+	return reminders, rows.Err()
+}
+
+func (s *SQLite) UpdateReminder(ctx context.Context, r *types.Reminder) error {
+	res, err := s.db.ExecContext(ctx, `UPDATE reminders SET next_attempt_at = ?, fired_at = ?, attempts = ?, last_error = ? WHERE id = ?`,
+		nullableTime(r.NextAttemptAt), nullableTime(r.FiredAt), r.Attempts, r.LastError, r.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update reminder: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%w: reminder %s", ErrNotFound, r.ID)
+	}
+	return nil
+}
+
+func (s *SQLite) DeleteReminder(ctx context.Context, id string) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM reminders WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete reminder: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%w: reminder %s", ErrNotFound, id)
+	}
+	return nil
+}
+
+func (s *SQLite) CancelReminders(ctx context.Context, applicationID string, kinds ...types.ReminderKind) (int, error) {
+	if len(kinds) == 0 {
+		return 0, nil
+	}
+	args := []any{applicationID}
+	for _, kind := range kinds {
+		args = append(args, kind)
+	}
+	res, err := s.db.ExecContext(ctx, `DELETE FROM reminders WHERE application_id = ? AND fired_at IS NULL
+		AND kind IN (?`+strings.Repeat(", ?", len(kinds)-1)+`)`, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to cancel reminders: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return int(n), nil
+}
+
+func (s *SQLite) CancelInterviewReminders(ctx context.Context, interviewID string) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM reminders WHERE interview_id = ? AND fired_at IS NULL`, interviewID); err != nil {
+		return fmt.Errorf("failed to cancel reminders: %w", err)
+	}
+	return nil
+}
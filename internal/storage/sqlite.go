@@ -0,0 +1,429 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	_ "modernc.org/sqlite"
+
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// SQLite is the Repository backed by a single SQLite file, through the pure Go driver
+// so the binary still builds without cgo.
+type SQLite struct {
+	db *sql.DB
+}
+
+var _ Repository = (*SQLite)(nil)
+
+// OpenSQLite opens (or creates) the database at path and brings its schema up to date.
+// ":memory:" gives a throwaway database.
+func OpenSQLite(path string) (*SQLite, error) {
+	dsn := "file:" + path + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
+	if path != ":memory:" {
+		dsn += "&_pragma=journal_mode(WAL)"
+	}
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	// one writer at a time is all SQLite does anyway, this avoids SQLITE_BUSY between
+	// our own connections (and keeps ":memory:" a single database)
+	db.SetMaxOpenConns(1)
+
+	s := &SQLite{db: db}
+	if err := s.migrate(context.Background()); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err := s.hashDescriptions(context.Background()); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// migrate applies every migrations/NNN_name.sql newer than the recorded version, each in
+// its own transaction.
+func (s *SQLite) migrate(ctx context.Context) error {
+	if _, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    INTEGER PRIMARY KEY,
+		name       TEXT NOT NULL,
+		applied_at DATETIME NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	var current int
+	if err := s.db.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&current); err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	files, err := fs.Glob(migrations, "migrations/*.sql")
+	if err != nil {
+		return err
+	}
+	slices.Sort(files)
+
+	for _, file := range files {
+		name := path.Base(file)
+		prefix, _, _ := strings.Cut(name, "_")
+		version, err := strconv.Atoi(prefix)
+		if err != nil {
+			return fmt.Errorf("migration %s has no numeric version prefix", name)
+		}
+		if version <= current {
+			continue
+		}
+
+		script, err := migrations.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		tx, err := s.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, string(script)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %s failed: %w", name, err)
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)`,
+			version, name, time.Now().UTC()); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to record migration %s: %w", name, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("migration %s failed: %w", name, err)
+		}
+		slog.Info("Applied database migration", "migration", name)
+	}
+	return nil
+}
+
+// hashDescriptions fills in description_hash for jobs saved before it existed, SQLite
+// has no hash function to do it in the migration.
+func (s *SQLite) hashDescriptions(ctx context.Context) error {
+	rows, err := s.db.QueryContext(ctx, `SELECT request_id, description FROM jobs WHERE description_hash = ''`)
+	if err != nil {
+		return fmt.Errorf("failed to load unhashed jobs: %w", err)
+	}
+	hashes := map[string]string{}
+	for rows.Next() {
+		var id, description string
+		if err := rows.Scan(&id, &description); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to load unhashed jobs: %w", err)
+		}
+		hashes[id] = descriptionHash(description)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to load unhashed jobs: %w", err)
+	}
+
+	for id, hash := range hashes {
+		if _, err := s.db.ExecContext(ctx, `UPDATE jobs SET description_hash = ? WHERE request_id = ?`, hash, id); err != nil {
+			return fmt.Errorf("failed to hash job %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// descriptionHash identifies a job description regardless of case and whitespace, the same
+// posting fetched twice rarely comes back byte for byte identical.
+func descriptionHash(description string) string {
+	normalized := strings.Join(strings.Fields(strings.ToLower(description)), " ")
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *SQLite) Ping(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
+func (s *SQLite) Close() error {
+	return s.db.Close()
+}
+
+func (s *SQLite) SaveJob(ctx context.Context, job *Job) error {
+	if job.CreatedAt.IsZero() {
+		job.CreatedAt = time.Now().UTC()
+	}
+	var company, position string
+	if job.Skills != nil {
+		company, position = job.Skills.CompanyInfo.Name, job.Skills.CompanyInfo.Position
+	}
+	skills, err := toJSON(job.Skills)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx, `INSERT INTO jobs (request_id, url, description, description_hash, company, position, skills, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (request_id) DO UPDATE SET url = excluded.url, description = excluded.description,
+			description_hash = excluded.description_hash, company = excluded.company, position = excluded.position,
+			skills = excluded.skills`,
+		job.RequestID, job.URL, job.Description, descriptionHash(job.Description), company, position, skills, job.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save job: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLite) GetJob(ctx context.Context, requestID string) (*Job, error) {
+	job := &Job{RequestID: requestID}
+	var skills sql.NullString
+	err := s.db.QueryRowContext(ctx, `SELECT url, description, skills, created_at FROM jobs WHERE request_id = ?`, requestID).
+		Scan(&job.URL, &job.Description, &skills, &job.CreatedAt)
+	if err != nil {
+		return nil, notFound(err, "job", requestID)
+	}
+	if skills.Valid {
+		if err := json.Unmarshal([]byte(skills.String), &job.Skills); err != nil {
+			return nil, fmt.Errorf("corrupt skills for job %s: %w", requestID, err)
+		}
+	}
+	return job, nil
+}
+
+func (s *SQLite) SaveResume(ctx context.Context, r types.Resume) (string, error) {
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+	data, err := toJSON(r)
+	if err != nil {
+		return "", err
+	}
+	now := time.Now().UTC()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO resumes (id, name, data, created_at, updated_at) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET name = excluded.name, data = excluded.data, updated_at = excluded.updated_at`,
+		r.ID, r.Name, data, now, now)
+	if err != nil {
+		return "", fmt.Errorf("failed to save resume: %w", err)
+	}
+	if err := insertVersion(ctx, tx, &types.ResumeVersion{ResumeID: r.ID, Note: "saved", Resume: r, CreatedAt: now}); err != nil {
+		return "", err
+	}
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("failed to save resume: %w", err)
+	}
+	return r.ID, nil
+}
+
+func (s *SQLite) GetResume(ctx context.Context, id string) (types.Resume, []types.TransformedItem, error) {
+	var data string
+	var chosen sql.NullString
+	err := s.db.QueryRowContext(ctx, `SELECT data, chosen FROM resumes WHERE id = ?`, id).Scan(&data, &chosen)
+	if err != nil {
+		return types.Resume{}, nil, notFound(err, "resume", id)
+	}
+
+	var r types.Resume
+	if err := json.Unmarshal([]byte(data), &r); err != nil {
+		return types.Resume{}, nil, fmt.Errorf("corrupt resume %s: %w", id, err)
+	}
+	var items []types.TransformedItem
+	if chosen.Valid {
+		if err := json.Unmarshal([]byte(chosen.String), &items); err != nil {
+			return types.Resume{}, nil, fmt.Errorf("corrupt chosen bullets for resume %s: %w", id, err)
+		}
+	}
+	return r, items, nil
+}
+
+func (s *SQLite) ChooseBullets(ctx context.Context, id string, items []types.TransformedItem) error {
+	chosen, err := toJSON(items)
+	if err != nil {
+		return err
+	}
+	res, err := s.db.ExecContext(ctx, `UPDATE resumes SET chosen = ?, updated_at = ? WHERE id = ?`, chosen, time.Now().UTC(), id)
+	if err != nil {
+		return fmt.Errorf("failed to save chosen bullets: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%w: resume %s", ErrNotFound, id)
+	}
+	return nil
+}
+
+func (s *SQLite) SaveScoreRun(ctx context.Context, run *ScoreRun) error {
+	if run.CreatedAt.IsZero() {
+		run.CreatedAt = time.Now().UTC()
+	}
+	result, err := toJSON(run.Result)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx, `INSERT INTO score_runs (request_id, job_id, resume_version_id, resume, overall_score, result,
+			model, prompt_version, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		run.RequestID, nullable(run.JobID), nullable(run.ResumeVersionID), run.Resume, run.Result.OverallScore, result,
+		run.Model, run.PromptVersion, run.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save score run: %w", err)
+	}
+	return nil
+}
+
+const scoreRunColumns = `r.request_id, r.job_id, r.resume_version_id, r.resume, r.result, r.model, r.prompt_version, r.created_at,
+	v.resume_id, v.number`
+
+const scoreRunFrom = ` FROM score_runs r LEFT JOIN resume_versions v ON v.id = r.resume_version_id`
+
+func (s *SQLite) GetScoreRun(ctx context.Context, requestID string) (*ScoreRun, error) {
+	run, err := scanScoreRun(s.db.QueryRowContext(ctx, `SELECT `+scoreRunColumns+scoreRunFrom+` WHERE r.request_id = ?`, requestID))
+	if err != nil {
+		return nil, notFound(err, "score run", requestID)
+	}
+	return run, nil
+}
+
+func (s *SQLite) ListScoreRuns(ctx context.Context, filter ScoreRunFilter) ([]ScoreRun, error) {
+	var where []string
+	var args []any
+	if filter.JobID != "" {
+		var hash string
+		err := s.db.QueryRowContext(ctx, `SELECT description_hash FROM jobs WHERE request_id = ?`, filter.JobID).Scan(&hash)
+		if err != nil {
+			return nil, notFound(err, "job", filter.JobID)
+		}
+		where = append(where, "r.job_id IN (SELECT request_id FROM jobs WHERE description_hash = ?)")
+		args = append(args, hash)
+	}
+	if filter.ResumeID != "" {
+		where = append(where, "v.resume_id = ?")
+		args = append(args, filter.ResumeID)
+	}
+
+	query := `SELECT ` + scoreRunColumns + scoreRunFrom
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY r.created_at, r.request_id"
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list score runs: %w", err)
+	}
+	defer rows.Close()
+
+	runs := []ScoreRun{}
+	for rows.Next() {
+		run, err := scanScoreRun(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list score runs: %w", err)
+		}
+		runs = append(runs, *run)
+	}
+	return runs, rows.Err()
+}
+
+func scanScoreRun(row scanner) (*ScoreRun, error) {
+	var run ScoreRun
+	var jobID, versionID, resumeID sql.NullString
+	var number sql.NullInt64
+	var result string
+	err := row.Scan(&run.RequestID, &jobID, &versionID, &run.Resume, &result, &run.Model, &run.PromptVersion, &run.CreatedAt,
+		&resumeID, &number)
+	if err != nil {
+		return nil, err
+	}
+	run.JobID, run.ResumeVersionID, run.ResumeID = jobID.String, versionID.String, resumeID.String
+	run.ResumeVersion = int(number.Int64)
+	if err := json.Unmarshal([]byte(result), &run.Result); err != nil {
+		return nil, fmt.Errorf("corrupt score run %s: %w", run.RequestID, err)
+	}
+	return &run, nil
+}
+
+func (s *SQLite) SaveTransform(ctx context.Context, t *Transform) error {
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = time.Now().UTC()
+	}
+	request, err := toJSON(t.Request)
+	if err != nil {
+		return err
+	}
+	result, err := toJSON(t.Result)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx, `INSERT INTO transforms (request_id, section, mode, request, result, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		t.RequestID, t.Request.Name, t.Result.Mode, request, result, t.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save transform: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLite) GetTransform(ctx context.Context, requestID string) (*Transform, error) {
+	t := &Transform{RequestID: requestID}
+	var request, result string
+	err := s.db.QueryRowContext(ctx, `SELECT request, result, created_at FROM transforms WHERE request_id = ?`, requestID).
+		Scan(&request, &result, &t.CreatedAt)
+	if err != nil {
+		return nil, notFound(err, "transform", requestID)
+	}
+	if err := json.Unmarshal([]byte(request), &t.Request); err != nil {
+		return nil, fmt.Errorf("corrupt transform %s: %w", requestID, err)
+	}
+	if err := json.Unmarshal([]byte(result), &t.Result); err != nil {
+		return nil, fmt.Errorf("corrupt transform %s: %w", requestID, err)
+	}
+	return t, nil
+}
+
+func toJSON(v any) (sql.NullString, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("failed to encode %T: %w", v, err)
+	}
+	if string(data) == "null" {
+		return sql.NullString{}, nil
+	}
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
+
+func nullable(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func nullableTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
+
+func notFound(err error, what, id string) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: %s %s", ErrNotFound, what, id)
+	}
+	return fmt.Errorf("failed to load %s %s: %w", what, id, err)
+}
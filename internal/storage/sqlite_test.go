@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+func openTest(t *testing.T) *SQLite {
+	t.Helper()
+	s, err := OpenSQLite(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestMigrateIsIdempotent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tracker.db")
+	for range 2 {
+		s, err := OpenSQLite(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Ping(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestJobsAndScoreRuns(t *testing.T) {
+	ctx := context.Background()
+	s := openTest(t)
+
+	skills := &types.ExtractedSkills{CompanyInfo: types.CompanyInfo{Name: "Globex", Position: "Engineer"}}
+	for _, id := range []string{"job-1", "job-2"} {
+		if err := s.SaveJob(ctx, &Job{RequestID: id, Description: "Build things in Go", Skills: skills}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	job, err := s.GetJob(ctx, "job-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.Skills == nil || job.Skills.CompanyInfo.Name != "Globex" {
+		t.Errorf("job = %+v", job)
+	}
+	if _, err := s.GetJob(ctx, "nope"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetJob(nope) error = %v, want ErrNotFound", err)
+	}
+
+	for i, jobID := range []string{"job-1", "job-2"} {
+		run := &ScoreRun{RequestID: "run-" + jobID, JobID: jobID, Resume: "resume", Result: &types.ScoredResume{OverallScore: float64(6 + i)}}
+		if err := s.SaveScoreRun(ctx, run); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the same description posted twice is the same job's history
+	runs, err := s.ListScoreRuns(ctx, ScoreRunFilter{JobID: "job-2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 2 || runs[1].Result.OverallScore != 7 {
+		t.Errorf("runs = %+v, want both runs", runs)
+	}
+}
+
+func TestCalendarTokens(t *testing.T) {
+	ctx := context.Background()
+	s := openTest(t)
+	if err := s.SetCalendarToken(ctx, "ada", "first"); err != nil {
+		t.Fatal(err)
+	}
+	if user, err := s.CalendarUser(ctx, "first"); err != nil || user != "ada" {
+		t.Errorf("CalendarUser(first) = %q, %v", user, err)
+	}
+
+	if err := s.SetCalendarToken(ctx, "ada", "second"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.CalendarUser(ctx, "first"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("rotated token error = %v, want ErrNotFound", err)
+	}
+	if user, err := s.CalendarUser(ctx, "second"); err != nil || user != "ada" {
+		t.Errorf("CalendarUser(second) = %q, %v", user, err)
+	}
+	if _, err := s.CalendarUser(ctx, "ada"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("a user ID opened the feed, error = %v", err)
+	}
+}
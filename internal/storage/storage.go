@@ -0,0 +1,193 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+var (
+	ErrNotFound = errors.New("not found")
+	ErrConflict = errors.New("conflict")
+)
+
+// Repository is everything the server persists. Job descriptions, score runs and
+// transforms are keyed by the ID of the request that produced them.
+type Repository interface {
+	SaveJob(ctx context.Context, job *Job) error
+	GetJob(ctx context.Context, requestID string) (*Job, error)
+
+	SaveResume(ctx context.Context, r types.Resume) (string, error)
+	GetResume(ctx context.Context, id string) (types.Resume, []types.TransformedItem, error)
+	// ChooseBullets replaces the set of transformed bullets used when rendering the resume.
+	ChooseBullets(ctx context.Context, id string, items []types.TransformedItem) error
+
+	// CreateResumeVersion numbers v after the latest version of its resume, which is also
+	// its parent unless ParentID is set. A version identical to the latest isn't stored
+	// again, v is filled in from the latest instead. SaveResume records a version itself.
+	CreateResumeVersion(ctx context.Context, v *types.ResumeVersion) error
+	GetResumeVersion(ctx context.Context, id string) (*types.ResumeVersion, error)
+	// ListResumeVersions returns every version of a resume, oldest first, with the
+	// applications and score runs that used it.
+	ListResumeVersions(ctx context.Context, resumeID string) ([]types.ResumeVersion, error)
+
+	SaveScoreRun(ctx context.Context, run *ScoreRun) error
+	GetScoreRun(ctx context.Context, requestID string) (*ScoreRun, error)
+	// ListScoreRuns returns matching score runs oldest first.
+	ListScoreRuns(ctx context.Context, filter ScoreRunFilter) ([]ScoreRun, error)
+
+	SaveTransform(ctx context.Context, t *Transform) error
+	GetTransform(ctx context.Context, requestID string) (*Transform, error)
+
+	CreateApplication(ctx context.Context, app *types.Application) error
+	GetApplication(ctx context.Context, id string) (*types.Application, error)
+	ListApplications(ctx context.Context, filter ApplicationFilter) ([]types.Application, error)
+	// UpdateApplication saves everything but the status, which only changes through SetStatus.
+	UpdateApplication(ctx context.Context, app *types.Application) error
+	// SetStatus moves an application from one status to another and records the change.
+	// It fails with ErrConflict when the application is no longer in from.
+	SetStatus(ctx context.Context, id string, from types.ApplicationStatus, change types.StatusChange) error
+	DeleteApplication(ctx context.Context, id string) error
+	// MergeApplications saves app (status and created_at included), moves the duplicate's
+	// status changes, interviews, reminders, contacts and interactions onto it and deletes the
+	// duplicate, all or nothing.
+	MergeApplications(ctx context.Context, app *types.Application, duplicateID string) error
+
+	CreateReminder(ctx context.Context, r *types.Reminder) error
+	ListReminders(ctx context.Context, filter ReminderFilter) ([]types.Reminder, error)
+	// UpdateReminder saves a reminder's delivery state: next_attempt_at, fired_at, attempts
+	// and last_error.
+	UpdateReminder(ctx context.Context, r *types.Reminder) error
+	DeleteReminder(ctx context.Context, id string) error
+	// CancelReminders deletes the application's unfired reminders of the given kinds.
+	CancelReminders(ctx context.Context, applicationID string, kinds ...types.ReminderKind) (int, error)
+	// CancelInterviewReminders deletes the unfired reminders for an interview.
+	CancelInterviewReminders(ctx context.Context, interviewID string) error
+
+	CreateInterview(ctx context.Context, i *types.Interview) error
+	GetInterview(ctx context.Context, id string) (*types.Interview, error)
+	ListInterviews(ctx context.Context, filter InterviewFilter) ([]types.Interview, error)
+	UpdateInterview(ctx context.Context, i *types.Interview) error
+	DeleteInterview(ctx context.Context, id string) error
+
+	// SetCalendarToken replaces the user's calendar feed token, the old one stops working.
+	SetCalendarToken(ctx context.Context, userID, token string) error
+	// CalendarUser returns whose feed a token opens.
+	CalendarUser(ctx context.Context, token string) (string, error)
+
+	CreateContact(ctx context.Context, c *types.Contact) error
+	GetContact(ctx context.Context, id string) (*types.Contact, error)
+	// ListContacts returns matching contacts by name, with their last contact date and links.
+	ListContacts(ctx context.Context, filter ContactFilter) ([]types.Contact, error)
+	UpdateContact(ctx context.Context, c *types.Contact) error
+	DeleteContact(ctx context.Context, id string) error
+	// LinkContact ties a contact to an application, linking it twice is a no-op.
+	LinkContact(ctx context.Context, applicationID, contactID string) error
+	UnlinkContact(ctx context.Context, applicationID, contactID string) error
+
+	CreateInteraction(ctx context.Context, i *types.Interaction) error
+	// ListInteractions returns matching interactions, latest first.
+	ListInteractions(ctx context.Context, filter InteractionFilter) ([]types.Interaction, error)
+	DeleteInteraction(ctx context.Context, id string) error
+
+	Ping(ctx context.Context) error
+	Close() error
+}
+
+// Job is a job description as it was given, and what was extracted from it.
+type Job struct {
+	RequestID   string                 `json:"request_id"`
+	URL         string                 `json:"url,omitempty"`
+	Description string                 `json:"description"`
+	Skills      *types.ExtractedSkills `json:"skills,omitempty"`
+	CreatedAt   time.Time              `json:"created_at"`
+}
+
+// ScoreRun is one /score call: the resume text sent, the result, and what produced it.
+type ScoreRun struct {
+	RequestID string `json:"request_id"`
+	JobID     string `json:"job_id"`
+	// ResumeVersionID is set when a stored resume version was scored
+	ResumeVersionID string              `json:"resume_version_id,omitempty"`
+	Resume          string              `json:"resume"`
+	Result          *types.ScoredResume `json:"result"`
+	Model           string              `json:"model,omitempty"`
+	PromptVersion   string              `json:"prompt_version,omitempty"`
+	CreatedAt       time.Time           `json:"created_at"`
+
+	// filled in on load from the resume version
+	ResumeID      string `json:"resume_id,omitempty"`
+	ResumeVersion int    `json:"resume_version,omitempty"`
+}
+
+// ScoreRunFilter narrows ListScoreRuns, zero values match everything.
+type ScoreRunFilter struct {
+	// JobID matches runs against this job and every other job with the same description
+	JobID    string
+	ResumeID string
+}
+
+// Transform is one /transformSection call.
+type Transform struct {
+	RequestID string                  `json:"request_id"`
+	Request   types.TransformRequest  `json:"request"`
+	Result    types.TransformResponse `json:"result"`
+	CreatedAt time.Time               `json:"created_at"`
+}
+
+// ApplicationFilter narrows ListApplications, zero values match everything.
+type ApplicationFilter struct {
+	UserID   string
+	Statuses []types.ApplicationStatus
+	// Company matches case-insensitively anywhere in the name
+	Company  string
+	MinScore *float64
+	Since    time.Time
+	Until    time.Time
+	Limit    int
+	Offset   int
+	// WithHistory loads each application's status history, which List leaves out otherwise
+	WithHistory bool
+}
+
+// ReminderFilter narrows ListReminders, zero values match everything. Reminders come
+// back in due order.
+type ReminderFilter struct {
+	UserID        string
+	ApplicationID string
+	// Pending only returns reminders that haven't fired
+	Pending   bool
+	DueBefore time.Time
+	// AttemptBefore only returns reminders to deliver by then: due by then, or due for a
+	// retry by then after a failed delivery
+	AttemptBefore time.Time
+	Limit         int
+}
+
+// InterviewFilter narrows ListInterviews, zero values match everything. Interviews come
+// back in start order.
+type InterviewFilter struct {
+	UserID        string
+	ApplicationID string
+	// Since only returns interviews ending after it
+	Since time.Time
+}
+
+// ContactFilter narrows ListContacts, zero values match everything.
+type ContactFilter struct {
+	UserID string
+	// Company matches case-insensitively anywhere in the name
+	Company       string
+	Role          types.ContactRole
+	ApplicationID string
+	// ContactedBefore only returns contacts last contacted before it, or never
+	ContactedBefore time.Time
+}
+
+// InteractionFilter narrows ListInteractions, zero values match everything.
+type InteractionFilter struct {
+	ContactID     string
+	ApplicationID string
+}
@@ -0,0 +1,20 @@
+// Package storagetest opens throwaway databases for tests of the packages on top of
+// storage.
+package storagetest
+
+import (
+	"testing"
+
+	"github.com/p-shah256/tracker/internal/storage"
+)
+
+// Open returns an empty, migrated in-memory database that's closed when the test ends.
+func Open(t testing.TB) *storage.SQLite {
+	t.Helper()
+	store, err := storage.OpenSQLite(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
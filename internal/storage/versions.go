@@ -0,0 +1,193 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+const versionColumns = `id, resume_id, parent_id, number, note, data, created_at`
+
+func (s *SQLite) CreateResumeVersion(ctx context.Context, v *types.ResumeVersion) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var exists int
+	if err := tx.QueryRowContext(ctx, `SELECT 1 FROM resumes WHERE id = ?`, v.ResumeID).Scan(&exists); err != nil {
+		return notFound(err, "resume", v.ResumeID)
+	}
+	if v.ParentID != "" {
+		var resumeID string
+		err := tx.QueryRowContext(ctx, `SELECT resume_id FROM resume_versions WHERE id = ?`, v.ParentID).Scan(&resumeID)
+		if err != nil {
+			return notFound(err, "resume version", v.ParentID)
+		}
+		if resumeID != v.ResumeID {
+			return fmt.Errorf("%w: resume version %s of resume %s", ErrNotFound, v.ParentID, v.ResumeID)
+		}
+	}
+
+	if err := insertVersion(ctx, tx, v); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to save resume version: %w", err)
+	}
+	return nil
+}
+
+// insertVersion adds v after the latest version of its resume, unless it's the same resume.
+func insertVersion(ctx context.Context, tx *sql.Tx, v *types.ResumeVersion) error {
+	v.Resume.ID = v.ResumeID
+	data, err := toJSON(v.Resume)
+	if err != nil {
+		return err
+	}
+
+	latest, err := scanVersion(tx.QueryRowContext(ctx, `SELECT `+versionColumns+` FROM resume_versions
+		WHERE resume_id = ? ORDER BY number DESC LIMIT 1`, v.ResumeID))
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		latest = nil
+	case err != nil:
+		return fmt.Errorf("failed to load latest resume version: %w", err)
+	}
+
+	if latest != nil {
+		// compare what would be stored, so field order and omitted fields don't matter
+		previous, err := toJSON(latest.Resume)
+		if err != nil {
+			return err
+		}
+		if previous == data {
+			*v = *latest
+			return nil
+		}
+		if v.ParentID == "" {
+			v.ParentID = latest.ID
+		}
+		v.Number = latest.Number + 1
+	} else {
+		v.Number = 1
+	}
+
+	if v.ID == "" {
+		v.ID = uuid.New().String()
+	}
+	if v.CreatedAt.IsZero() {
+		v.CreatedAt = time.Now()
+	}
+	v.CreatedAt = v.CreatedAt.UTC()
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO resume_versions (`+versionColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		v.ID, v.ResumeID, nullable(v.ParentID), v.Number, v.Note, data, v.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save resume version: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLite) GetResumeVersion(ctx context.Context, id string) (*types.ResumeVersion, error) {
+	v, err := scanVersion(s.db.QueryRowContext(ctx, `SELECT `+versionColumns+` FROM resume_versions WHERE id = ?`, id))
+	if err != nil {
+		return nil, notFound(err, "resume version", id)
+	}
+	return v, nil
+}
+
+func (s *SQLite) ListResumeVersions(ctx context.Context, resumeID string) ([]types.ResumeVersion, error) {
+	var exists int
+	if err := s.db.QueryRowContext(ctx, `SELECT 1 FROM resumes WHERE id = ?`, resumeID).Scan(&exists); err != nil {
+		return nil, notFound(err, "resume", resumeID)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT `+versionColumns+` FROM resume_versions WHERE resume_id = ? ORDER BY number`, resumeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resume versions: %w", err)
+	}
+	versions := []types.ResumeVersion{}
+	byID := map[string]int{}
+	for rows.Next() {
+		v, err := scanVersion(rows)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to list resume versions: %w", err)
+		}
+		byID[v.ID] = len(versions)
+		versions = append(versions, *v)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list resume versions: %w", err)
+	}
+
+	rows, err = s.db.QueryContext(ctx, `SELECT a.resume_version_id, a.id, a.company, a.position, a.status, a.score
+		FROM applications a JOIN resume_versions v ON v.id = a.resume_version_id
+		WHERE v.resume_id = ? ORDER BY a.created_at, a.id`, resumeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load version applications: %w", err)
+	}
+	for rows.Next() {
+		var versionID string
+		var app types.VersionApplication
+		var score sql.NullFloat64
+		if err := rows.Scan(&versionID, &app.ID, &app.Company, &app.Position, &app.Status, &score); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to load version applications: %w", err)
+		}
+		if score.Valid {
+			app.Score = &score.Float64
+		}
+		i := byID[versionID]
+		versions[i].Applications = append(versions[i].Applications, app)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to load version applications: %w", err)
+	}
+
+	rows, err = s.db.QueryContext(ctx, `SELECT r.resume_version_id, r.request_id, r.job_id, j.company, j.position, r.overall_score, r.created_at
+		FROM score_runs r JOIN resume_versions v ON v.id = r.resume_version_id
+		LEFT JOIN jobs j ON j.request_id = r.job_id
+		WHERE v.resume_id = ? ORDER BY r.created_at, r.request_id`, resumeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load version scores: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var versionID string
+		var score types.VersionScore
+		var jobID, company, position sql.NullString
+		if err := rows.Scan(&versionID, &score.RequestID, &jobID, &company, &position, &score.OverallScore, &score.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to load version scores: %w", err)
+		}
+		score.JobID, score.Company, score.Position = jobID.String, company.String, position.String
+		i := byID[versionID]
+		versions[i].Scores = append(versions[i].Scores, score)
+	}
+	return versions, rows.Err()
+}
+
+func scanVersion(row scanner) (*types.ResumeVersion, error) {
+	var v types.ResumeVersion
+	var parentID sql.NullString
+	var data string
+	if err := row.Scan(&v.ID, &v.ResumeID, &parentID, &v.Number, &v.Note, &data, &v.CreatedAt); err != nil {
+		return nil, err
+	}
+	v.ParentID = parentID.String
+	if err := json.Unmarshal([]byte(data), &v.Resume); err != nil {
+		return nil, fmt.Errorf("corrupt resume version %s: %w", v.ID, err)
+	}
+	return &v, nil
+}
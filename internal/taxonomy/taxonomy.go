@@ -0,0 +1,265 @@
+package taxonomy
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+type Category string
+
+const (
+	CategoryLanguage    Category = "language"
+	CategoryFramework   Category = "framework"
+	CategoryCloud       Category = "cloud"
+	CategoryDatabase    Category = "database"
+	CategoryTool        Category = "tool"
+	CategoryMethodology Category = "methodology"
+	CategorySoftSkill   Category = "soft_skill"
+	CategoryDomain      Category = "domain"
+)
+
+var categories = []Category{
+	CategoryLanguage, CategoryFramework, CategoryCloud, CategoryDatabase,
+	CategoryTool, CategoryMethodology, CategorySoftSkill, CategoryDomain,
+}
+
+//go:embed skills.yaml
+var defaultSkills []byte
+
+type Skill struct {
+	ID       string   `yaml:"id" json:"id"`
+	Name     string   `yaml:"name" json:"name"`
+	Category Category `yaml:"category" json:"category"`
+	Aliases  []string `yaml:"aliases,omitempty" json:"aliases,omitempty"`
+	Parent   string   `yaml:"parent,omitempty" json:"parent,omitempty"`
+}
+
+type file struct {
+	Skills []Skill `yaml:"skills"`
+}
+
+// Taxonomy maps the many spellings of a skill to one canonical entry.
+type Taxonomy struct {
+	skills   map[string]*Skill
+	index    map[string]string
+	children map[string][]string
+}
+
+// Default is the taxonomy shipped in skills.yaml.
+func Default() *Taxonomy {
+	t, err := Parse(defaultSkills)
+	if err != nil {
+		panic(fmt.Sprintf("taxonomy: embedded skills.yaml is invalid: %v", err))
+	}
+	return t
+}
+
+// Load reads an edited copy of skills.yaml from disk.
+func Load(path string) (*Taxonomy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read skill taxonomy: %w", err)
+	}
+	t, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid skill taxonomy %s: %w", path, err)
+	}
+	return t, nil
+}
+
+func Parse(data []byte) (*Taxonomy, error) {
+	var f file
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+
+	t := &Taxonomy{
+		skills:   make(map[string]*Skill, len(f.Skills)),
+		index:    make(map[string]string),
+		children: make(map[string][]string),
+	}
+	for i := range f.Skills {
+		skill := &f.Skills[i]
+		if skill.ID == "" || skill.Name == "" {
+			return nil, fmt.Errorf("skill #%d needs both id and name", i+1)
+		}
+		if _, dup := t.skills[skill.ID]; dup {
+			return nil, fmt.Errorf("duplicate skill id %q", skill.ID)
+		}
+		if !slices.Contains(categories, skill.Category) {
+			return nil, fmt.Errorf("skill %q has unknown category %q", skill.ID, skill.Category)
+		}
+		t.skills[skill.ID] = skill
+
+		for _, alias := range append([]string{skill.Name, skill.ID}, skill.Aliases...) {
+			k := key(alias)
+			if owner, taken := t.index[k]; taken && owner != skill.ID {
+				return nil, fmt.Errorf("alias %q is used by both %q and %q", alias, owner, skill.ID)
+			}
+			t.index[k] = skill.ID
+		}
+	}
+
+	for id, skill := range t.skills {
+		if skill.Parent == "" {
+			continue
+		}
+		if _, ok := t.skills[skill.Parent]; !ok {
+			return nil, fmt.Errorf("skill %q has unknown parent %q", id, skill.Parent)
+		}
+		t.children[skill.Parent] = append(t.children[skill.Parent], id)
+	}
+	for id := range t.skills {
+		if t.cyclic(id) {
+			return nil, fmt.Errorf("skill %q is its own ancestor", id)
+		}
+	}
+	return t, nil
+}
+
+func (t *Taxonomy) cyclic(id string) bool {
+	seen := map[string]bool{}
+	for id != "" {
+		if seen[id] {
+			return true
+		}
+		seen[id] = true
+		id = t.skills[id].Parent
+	}
+	return false
+}
+
+var parenthetical = regexp.MustCompile(`^(.*?)\s*\(([^)]*)\)\s*$`)
+
+// Lookup finds the canonical skill for a name. "Kubernetes (EKS)" is tried as written,
+// then as "Kubernetes", then as "EKS".
+func (t *Taxonomy) Lookup(name string) (*Skill, bool) {
+	if id, ok := t.index[key(name)]; ok {
+		return t.skills[id], true
+	}
+	if m := parenthetical.FindStringSubmatch(strings.TrimSpace(name)); m != nil {
+		for _, candidate := range []string{m[1], m[2]} {
+			if id, ok := t.index[key(candidate)]; ok {
+				return t.skills[id], true
+			}
+		}
+	}
+	return nil, false
+}
+
+func (t *Taxonomy) Get(id string) (*Skill, bool) {
+	skill, ok := t.skills[id]
+	return skill, ok
+}
+
+// Ancestors lists the parent chain of a skill, closest first.
+func (t *Taxonomy) Ancestors(id string) []string {
+	var out []string
+	for skill, ok := t.skills[id]; ok && skill.Parent != ""; skill, ok = t.skills[skill.Parent] {
+		out = append(out, skill.Parent)
+	}
+	return out
+}
+
+func (t *Taxonomy) Children(id string) []string {
+	children := slices.Clone(t.children[id])
+	slices.Sort(children)
+	return children
+}
+
+// Aliases returns every spelling that maps to the skill, canonical name first.
+func (t *Taxonomy) Aliases(id string) []string {
+	skill, ok := t.skills[id]
+	if !ok {
+		return nil
+	}
+	return append([]string{skill.Name}, skill.Aliases...)
+}
+
+func (t *Taxonomy) Skills() []Skill {
+	out := make([]Skill, 0, len(t.skills))
+	for _, skill := range t.skills {
+		out = append(out, *skill)
+	}
+	slices.SortFunc(out, func(a, b Skill) int { return strings.Compare(a.ID, b.ID) })
+	return out
+}
+
+// Normalize rewrites a skill to its canonical name and fills in ID and category.
+// Skills the taxonomy doesn't know keep their name, trimmed.
+func (t *Taxonomy) Normalize(s types.ExtractedSkill) types.ExtractedSkill {
+	s.Name = strings.TrimSpace(s.Name)
+	if skill, ok := t.Lookup(s.Name); ok {
+		s.ID = skill.ID
+		s.Name = skill.Name
+		s.Category = string(skill.Category)
+	}
+	return s
+}
+
+// NormalizeSkills normalizes every skill and merges duplicates, keeping the highest importance.
+func (t *Taxonomy) NormalizeSkills(skills []types.ExtractedSkill) []types.ExtractedSkill {
+	out := make([]types.ExtractedSkill, 0, len(skills))
+	seen := make(map[string]int, len(skills))
+	for _, s := range skills {
+		s = t.Normalize(s)
+		if s.Name == "" {
+			continue
+		}
+		k := s.ID
+		if k == "" {
+			k = key(s.Name)
+		}
+		if i, dup := seen[k]; dup {
+			out[i].Importance = max(out[i].Importance, s.Importance)
+			continue
+		}
+		seen[k] = len(out)
+		out = append(out, s)
+	}
+	return out
+}
+
+// NormalizeExtracted cleans up an LLM extraction in place. A skill listed as both
+// required and nice-to-have is only kept as required.
+func (t *Taxonomy) NormalizeExtracted(e *types.ExtractedSkills) {
+	e.RequiredSkills = t.NormalizeSkills(e.RequiredSkills)
+	e.NiceToHaveSkills = t.NormalizeSkills(e.NiceToHaveSkills)
+
+	required := make(map[string]bool, len(e.RequiredSkills))
+	for _, s := range e.RequiredSkills {
+		required[SkillKey(s)] = true
+	}
+	e.NiceToHaveSkills = slices.DeleteFunc(e.NiceToHaveSkills, func(s types.ExtractedSkill) bool {
+		return required[SkillKey(s)]
+	})
+}
+
+// SkillKey identifies a normalized skill, by ID when the taxonomy knows it.
+func SkillKey(s types.ExtractedSkill) string {
+	if s.ID != "" {
+		return s.ID
+	}
+	return key(s.Name)
+}
+
+// key ignores case, whitespace, dots, dashes and underscores so "Node JS" finds "node.js".
+func key(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		switch r {
+		case ' ', '\t', '.', '-', '_':
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
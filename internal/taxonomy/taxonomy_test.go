@@ -0,0 +1,125 @@
+package taxonomy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+func TestDefaultParses(t *testing.T) {
+	if len(Default().Skills()) == 0 {
+		t.Fatal("embedded taxonomy has no skills")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want string
+	}{
+		{"missing name", "skills:\n  - id: go\n    category: language\n", "needs both id and name"},
+		{"duplicate id", "skills:\n  - {id: go, name: Go, category: language}\n  - {id: go, name: Golang, category: language}\n", "duplicate skill id"},
+		{"unknown category", "skills:\n  - {id: go, name: Go, category: vibes}\n", "unknown category"},
+		{"shared alias", "skills:\n  - {id: go, name: Go, category: language, aliases: [g]}\n  - {id: gleam, name: Gleam, category: language, aliases: [G]}\n", "is used by both"},
+		{"unknown parent", "skills:\n  - {id: gin, name: Gin, category: framework, parent: go}\n", "unknown parent"},
+		{"cycle", "skills:\n  - {id: a, name: A, category: tool, parent: b}\n  - {id: b, name: B, category: tool, parent: a}\n", "its own ancestor"},
+		{"bad yaml", "skills: [", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.yaml))
+			if err == nil {
+				t.Fatal("Parse succeeded")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error %q doesn't mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	tax := Default()
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Kubernetes", "kubernetes"},
+		{"k8s", "kubernetes"},
+		{"  KUBE ", "kubernetes"},
+		{"Node JS", "nodejs"},
+		{"node.js", "nodejs"},
+		{"Postgres", "postgresql"},
+		{"Kubernetes (EKS)", "kubernetes"},
+		{"Managed clusters (EKS)", "eks"},
+		{"Amazon Web Services", "aws"},
+		{"COBOL-ish things", ""},
+	}
+	for _, tt := range tests {
+		skill, ok := tax.Lookup(tt.name)
+		got := ""
+		if ok {
+			got = skill.ID
+		}
+		if got != tt.want {
+			t.Errorf("Lookup(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestHierarchy(t *testing.T) {
+	tax := Default()
+	if got := strings.Join(tax.Ancestors("express"), ","); got != "nodejs,javascript" {
+		t.Errorf("Ancestors(express) = %s", got)
+	}
+	if got := tax.Ancestors("javascript"); len(got) != 0 {
+		t.Errorf("Ancestors(javascript) = %v, want none", got)
+	}
+	children := tax.Children("javascript")
+	for _, want := range []string{"nodejs", "react", "vue"} {
+		if !strings.Contains(strings.Join(children, ","), want) {
+			t.Errorf("Children(javascript) = %v, missing %s", children, want)
+		}
+	}
+	if got := tax.Aliases("kubernetes"); len(got) == 0 || got[0] != "Kubernetes" {
+		t.Errorf("Aliases(kubernetes) = %v, want the canonical name first", got)
+	}
+}
+
+func TestNormalizeExtracted(t *testing.T) {
+	tax := Default()
+	extracted := &types.ExtractedSkills{
+		RequiredSkills: []types.ExtractedSkill{
+			{Name: "golang", Importance: 3},
+			{Name: "k8s", Importance: 2},
+			{Name: "Kubernetes", Importance: 5},
+			{Name: " Pottery ", Importance: 1},
+			{Name: "pottery", Importance: 2},
+			{Name: "  "},
+		},
+		NiceToHaveSkills: []types.ExtractedSkill{
+			{Name: "kube", Importance: 1},
+			{Name: "JS", Importance: 2},
+		},
+	}
+	tax.NormalizeExtracted(extracted)
+
+	want := []types.ExtractedSkill{
+		{Name: "Go", Importance: 3, ID: "go", Category: "language"},
+		{Name: "Kubernetes", Importance: 5, ID: "kubernetes", Category: "cloud"},
+		{Name: "Pottery", Importance: 2},
+	}
+	if len(extracted.RequiredSkills) != len(want) {
+		t.Fatalf("required = %+v, want %+v", extracted.RequiredSkills, want)
+	}
+	for i := range want {
+		if extracted.RequiredSkills[i] != want[i] {
+			t.Errorf("required[%d] = %+v, want %+v", i, extracted.RequiredSkills[i], want[i])
+		}
+	}
+	if len(extracted.NiceToHaveSkills) != 1 || extracted.NiceToHaveSkills[0].ID != "javascript" {
+		t.Errorf("nice to have = %+v, want only JavaScript", extracted.NiceToHaveSkills)
+	}
+}
@@ -0,0 +1,87 @@
+package verify
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+const (
+	KindLength     = "length"
+	KindAddedSkill = "added_skill"
+)
+
+// LengthBand is the range a rewrite's length may move in, as a fraction of the original.
+type LengthBand float64
+
+const DefaultLengthBand LengthBand = 0.25
+
+// Bounds returns the shortest and longest allowed rewrite of a bullet of n characters.
+func (b LengthBand) Bounds(n int) (int, int) {
+	return int(math.Ceil(float64(n) * (1 - float64(b)))), int(math.Floor(float64(n) * (1 + float64(b))))
+}
+
+func (b LengthBand) Contains(original, rewritten int) bool {
+	lo, hi := b.Bounds(original)
+	return rewritten >= lo && rewritten <= hi
+}
+
+// CountChars replaces the character counts the model reported with real ones.
+func CountChars(items []types.TransformedItem) {
+	for i := range items {
+		items[i].CharCountOriginal = utf8.RuneCountInString(items[i].OriginalBullet)
+		items[i].CharCountNew = utf8.RuneCountInString(items[i].TransformedBullet)
+	}
+}
+
+// CheckLength reports a rewrite outside the band, counts must be up to date.
+func CheckLength(item types.TransformedItem, band LengthBand) *types.Finding {
+	if band.Contains(item.CharCountOriginal, item.CharCountNew) {
+		return nil
+	}
+	lo, hi := band.Bounds(item.CharCountOriginal)
+	return &types.Finding{
+		Kind:    KindLength,
+		Text:    fmt.Sprintf("%d", item.CharCountNew),
+		Message: fmt.Sprintf("rewrite is %d characters, expected %d-%d", item.CharCountNew, lo, hi),
+	}
+}
+
+// CheckAddedSkills keeps the added skills that the rewritten bullet actually mentions
+// and reports the rest.
+func (g *Guard) CheckAddedSkills(item types.TransformedItem) ([]string, []types.Finding) {
+	var kept []string
+	var findings []types.Finding
+	for _, skill := range item.AddedSkills {
+		if g.mentions(item.TransformedBullet, skill) {
+			kept = append(kept, skill)
+			continue
+		}
+		findings = append(findings, types.Finding{
+			Kind:    KindAddedSkill,
+			Text:    skill,
+			Message: fmt.Sprintf("%s is listed as added but the rewrite doesn't mention it", skill),
+		})
+	}
+	return kept, findings
+}
+
+// mentions checks for the skill by name, or by any spelling of it or a more specific
+// skill under it when the taxonomy knows it.
+func (g *Guard) mentions(text, skill string) bool {
+	if known, ok := g.taxonomy.Lookup(skill); ok {
+		for _, m := range g.technologyMentions(text) {
+			if m.id == known.ID || slices.Contains(g.taxonomy.Ancestors(m.id), known.ID) {
+				return true
+			}
+		}
+	}
+	pattern := `(?i)(^|[^\pL\pN])` + regexp.QuoteMeta(strings.TrimSpace(skill)) + `($|[^\pL\pN])`
+	ok, _ := regexp.MatchString(pattern, text)
+	return ok
+}
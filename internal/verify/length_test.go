@@ -0,0 +1,70 @@
+package verify
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/p-shah256/tracker/internal/taxonomy"
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+func TestLengthBand(t *testing.T) {
+	tests := []struct {
+		band      LengthBand
+		original  int
+		lo, hi    int
+		rewritten int
+		contains  bool
+	}{
+		{DefaultLengthBand, 100, 75, 125, 125, true},
+		{DefaultLengthBand, 100, 75, 125, 126, false},
+		{DefaultLengthBand, 100, 75, 125, 74, false},
+		{DefaultLengthBand, 30, 23, 37, 37, true},
+		{0.1, 50, 45, 55, 44, false},
+		{0, 40, 40, 40, 40, true},
+	}
+	for _, tt := range tests {
+		lo, hi := tt.band.Bounds(tt.original)
+		if lo != tt.lo || hi != tt.hi {
+			t.Errorf("%v.Bounds(%d) = %d-%d, want %d-%d", tt.band, tt.original, lo, hi, tt.lo, tt.hi)
+		}
+		if got := tt.band.Contains(tt.original, tt.rewritten); got != tt.contains {
+			t.Errorf("%v.Contains(%d, %d) = %v, want %v", tt.band, tt.original, tt.rewritten, got, tt.contains)
+		}
+	}
+}
+
+func TestCountChars(t *testing.T) {
+	items := []types.TransformedItem{{
+		OriginalBullet:    "Café ordering in Go",
+		TransformedBullet: "Built café ordering in Go — 2× faster",
+		CharCountOriginal: 99,
+		CharCountNew:      99,
+	}}
+	CountChars(items)
+	if items[0].CharCountOriginal != 19 || items[0].CharCountNew != 37 {
+		t.Errorf("counts = %d, %d, want runes 19, 37", items[0].CharCountOriginal, items[0].CharCountNew)
+	}
+	if f := CheckLength(items[0], DefaultLengthBand); f == nil || f.Kind != KindLength {
+		t.Errorf("CheckLength = %+v, want a length finding", f)
+	}
+}
+
+func TestCheckAddedSkills(t *testing.T) {
+	g := New(taxonomy.Default())
+	item := types.TransformedItem{
+		TransformedBullet: "Moved the services to EKS and wrote the Terraform for it",
+		AddedSkills:       []string{"Kubernetes", "terraform", "Kafka", "Team building"},
+	}
+	kept, findings := g.CheckAddedSkills(item)
+	if strings.Join(kept, ",") != "Kubernetes,terraform" {
+		t.Errorf("kept = %v, want Kubernetes through EKS and terraform", kept)
+	}
+	var reported []string
+	for _, f := range findings {
+		reported = append(reported, f.Text)
+	}
+	if strings.Join(reported, ",") != "Kafka,Team building" {
+		t.Errorf("reported = %v", reported)
+	}
+}
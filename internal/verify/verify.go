@@ -0,0 +1,325 @@
+package verify
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/p-shah256/tracker/internal/taxonomy"
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+const (
+	KindNumber     = "number"
+	KindEntity     = "entity"
+	KindTechnology = "technology"
+	KindClaim      = "claim"
+)
+
+// Guard checks rewritten text against the text it was written from and reports anything
+// that appears out of nowhere: numbers, organisations, technologies and achievement claims.
+type Guard struct {
+	taxonomy *taxonomy.Taxonomy
+}
+
+func New(t *taxonomy.Taxonomy) *Guard {
+	return &Guard{taxonomy: t}
+}
+
+var (
+	numberPattern = regexp.MustCompile(`\$?\d[\d,]*(?:\.\d+)?\s?(?:%|[kKmMbB]\b|x\b|\+)?`)
+	digitsOnly    = regexp.MustCompile(`[^\d.]`)
+	wordPattern   = regexp.MustCompile(`[A-Za-z][A-Za-z0-9+#.&'/-]*`)
+)
+
+// claimWords are verbs and nouns that assert scope or achievement. They are fine when the
+// source says the same thing in some form, and fabrication when it doesn't.
+var claimWords = []string{
+	"led", "managed", "manage", "mentored", "mentor", "architected", "founded", "co-founded",
+	"spearheaded", "launched", "owned", "headed", "directed", "supervised", "patented", "patent",
+	"award", "awarded", "promoted", "published", "hired", "recruited", "negotiated",
+	"generated", "revenue", "million", "billion",
+}
+
+// claimPhrases are claims made of everyday words, "first", "only" and "saved" are only a
+// claim in context: "first to ship", not "first-pass review".
+var claimPhrases = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)\bfirst (to|ever|engineer|hire|employee|developer|person)\b`),
+	regexp.MustCompile(`(?i)\bsole (owner|engineer|developer|maintainer|author|contributor|architect|person)\b`),
+	regexp.MustCompile(`(?i)\bonly (engineer|developer|person|one|member|hire|woman|man)\b`),
+	regexp.MustCompile(`(?i)\bsaved (the (company|business|team)|over|more than|millions|\$|\d)`),
+}
+
+// commonCapitalized are capitalized words that say nothing about who or where.
+var commonCapitalized = map[string]bool{
+	"i": true, "a": true, "an": true, "the": true, "and": true, "or": true, "of": true, "for": true,
+	"to": true, "in": true, "on": true, "with": true, "by": true, "via": true, "using": true,
+	"i'm": true, "i've": true, "i'd": true, "i'll": true,
+	"api": true, "apis": true, "ui": true, "ux": true, "q1": true, "q2": true, "q3": true, "q4": true,
+}
+
+// CheckBullet lists what the rewritten bullet claims that neither the original bullet
+// nor the rest of the resume supports.
+func (g *Guard) CheckBullet(original, rewritten, resume string) []types.Finding {
+	if strings.TrimSpace(rewritten) == "" {
+		return nil
+	}
+	source := original + "\n" + resume
+	return g.check(rewritten, source, source)
+}
+
+// CheckText checks free text written from a resume for a job, like a cover letter.
+// Names from the job description (the company, its products) may be used, but numbers,
+// technologies and claims about the candidate still have to come from the resume.
+func (g *Guard) CheckText(text, resume, job string) []types.Finding {
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+	return g.check(text, resume, resume+"\n"+job)
+}
+
+func (g *Guard) check(text, facts, names string) []types.Finding {
+	var findings []types.Finding
+	findings = append(findings, g.newNumbers(facts, text)...)
+	techFindings, techWords := g.newTechnologies(facts, text)
+	findings = append(findings, techFindings...)
+	findings = append(findings, g.newEntities(names, text, techWords)...)
+	findings = append(findings, g.newClaims(facts, text)...)
+	return findings
+}
+
+func (g *Guard) newNumbers(source, rewritten string) []types.Finding {
+	known := map[string]bool{}
+	for _, n := range numberPattern.FindAllString(source, -1) {
+		known[numberCore(n)] = true
+	}
+
+	var findings []types.Finding
+	seen := map[string]bool{}
+	for _, n := range numberPattern.FindAllString(rewritten, -1) {
+		core := numberCore(n)
+		if core == "" || known[core] || seen[core] {
+			continue
+		}
+		seen[core] = true
+		findings = append(findings, types.Finding{
+			Kind:    KindNumber,
+			Text:    strings.TrimSpace(n),
+			Message: fmt.Sprintf("%q does not appear in the original bullet or resume", strings.TrimSpace(n)),
+		})
+	}
+	return findings
+}
+
+func numberCore(n string) string {
+	return strings.TrimRight(digitsOnly.ReplaceAllString(n, ""), ".")
+}
+
+// newTechnologies also returns the words that were recognised as technologies, so they
+// are not reported a second time as unknown names.
+func (g *Guard) newTechnologies(source, rewritten string) ([]types.Finding, map[string]bool) {
+	// a specific skill backs up the broader one: EKS on the resume covers "Kubernetes"
+	allowed := map[string]bool{}
+	for _, id := range g.technologies(source) {
+		allowed[id] = true
+		for _, ancestor := range g.taxonomy.Ancestors(id) {
+			allowed[ancestor] = true
+		}
+	}
+
+	var findings []types.Finding
+	techWords := map[string]bool{}
+	for _, m := range g.technologyMentions(rewritten) {
+		for _, w := range strings.Fields(m.text) {
+			techWords[strings.ToLower(w)] = true
+		}
+		if allowed[m.id] {
+			continue
+		}
+		allowed[m.id] = true
+		findings = append(findings, types.Finding{
+			Kind:    KindTechnology,
+			Text:    m.text,
+			Message: fmt.Sprintf("%s is not mentioned anywhere in the resume", m.text),
+		})
+	}
+	return findings, techWords
+}
+
+type mention struct {
+	id   string
+	text string
+}
+
+func (g *Guard) technologies(text string) []string {
+	var ids []string
+	for _, m := range g.technologyMentions(text) {
+		if !slices.Contains(ids, m.id) {
+			ids = append(ids, m.id)
+		}
+	}
+	return ids
+}
+
+// technologyMentions looks up every 1-3 word window in the taxonomy, longest first.
+func (g *Guard) technologyMentions(text string) []mention {
+	words := wordPattern.FindAllString(text, -1)
+	for i, w := range words {
+		words[i] = strings.TrimRight(w, ".,/'")
+	}
+
+	var mentions []mention
+	for i := 0; i < len(words); {
+		matched := 0
+		for n := min(3, len(words)-i); n >= 1; n-- {
+			phrase := strings.Join(words[i:i+n], " ")
+			// "go", "c" and friends only count in their proper spelling
+			if n == 1 && len(phrase) <= 2 && !unicode.IsUpper(rune(phrase[0])) {
+				continue
+			}
+			if skill, ok := g.taxonomy.Lookup(phrase); ok {
+				mentions = append(mentions, mention{id: skill.ID, text: phrase})
+				matched = n
+				break
+			}
+		}
+		i += max(matched, 1)
+	}
+	return mentions
+}
+
+// newEntities finds runs of capitalized words (names of employers, products, customers)
+// that the source never mentions. The first word of a sentence is skipped, it is
+// capitalized either way.
+func (g *Guard) newEntities(source, rewritten string, techWords map[string]bool) []types.Finding {
+	lowerSource := strings.ToLower(source)
+	words := strings.Fields(rewritten)
+
+	var findings []types.Finding
+	var run []string
+	flush := func() {
+		if len(run) == 0 {
+			return
+		}
+		name := strings.Join(run, " ")
+		run = nil
+		if strings.Contains(lowerSource, strings.ToLower(name)) {
+			return
+		}
+		findings = append(findings, types.Finding{
+			Kind:    KindEntity,
+			Text:    name,
+			Message: fmt.Sprintf("%q is not mentioned in the original bullet or resume", name),
+		})
+	}
+
+	sentenceStart := true
+	for _, raw := range words {
+		word := strings.Trim(raw, `.,;:()"'!?`)
+		possessive := strings.HasSuffix(word, "'s") || strings.HasSuffix(word, "’s")
+		word = strings.TrimSuffix(strings.TrimSuffix(word, "'s"), "’s")
+		capitalized := word != "" && unicode.IsUpper([]rune(word)[0])
+		skip := sentenceStart || commonCapitalized[strings.ToLower(word)] || techWords[strings.ToLower(word)]
+		if capitalized && !skip {
+			run = append(run, word)
+		} else {
+			flush()
+		}
+		// punctuation and possessives end a name: "Acme, Globex" and "Acme's Payments team" are two
+		if possessive || strings.ContainsAny(raw, ".,;:()") {
+			flush()
+		}
+		sentenceStart = strings.ContainsAny(raw[len(raw)-1:], ".!?")
+	}
+	flush()
+	return findings
+}
+
+func (g *Guard) newClaims(source, rewritten string) []types.Finding {
+	sourceWords := wordSet(source)
+	var findings []types.Finding
+	for w := range wordSet(rewritten) {
+		if !slices.Contains(claimWords, w) || sourceWords[w] {
+			continue
+		}
+		// "led" is backed up by "lead", "managed" by "management"
+		if hasSameRoot(w, sourceWords) {
+			continue
+		}
+		findings = append(findings, types.Finding{
+			Kind:    KindClaim,
+			Text:    w,
+			Message: fmt.Sprintf("claims %q, which nothing in the original bullet or resume supports", w),
+		})
+	}
+	for _, phrase := range claimPhrases {
+		claim := phrase.FindString(rewritten)
+		if claim == "" || phrase.MatchString(source) {
+			continue
+		}
+		findings = append(findings, types.Finding{
+			Kind:    KindClaim,
+			Text:    strings.ToLower(claim),
+			Message: fmt.Sprintf("claims %q, which nothing in the original bullet or resume supports", strings.ToLower(claim)),
+		})
+	}
+	slices.SortFunc(findings, func(a, b types.Finding) int { return strings.Compare(a.Text, b.Text) })
+	return findings
+}
+
+func hasSameRoot(word string, words map[string]bool) bool {
+	root := word
+	if len(root) > 4 {
+		root = root[:4]
+	}
+	if word == "led" {
+		root = "lead"
+	}
+	for w := range words {
+		if strings.HasPrefix(w, root) {
+			return true
+		}
+	}
+	return false
+}
+
+func wordSet(text string) map[string]bool {
+	set := map[string]bool{}
+	for _, w := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '-'
+	}) {
+		set[w] = true
+	}
+	return set
+}
+
+// Verify checks every transformed bullet. In truthful mode a bullet with findings is
+// rejected and its rewrite replaced by the original, otherwise findings are only reported.
+// A rewrite outside the length band is rejected in either mode.
+func (g *Guard) Verify(items []types.TransformedItem, resume string, truthful bool, band LengthBand) []types.TransformedItem {
+	CountChars(items)
+	for i := range items {
+		item := &items[i]
+		item.Findings = g.CheckBullet(item.OriginalBullet, item.TransformedBullet, resume)
+		reject := truthful && len(item.Findings) > 0
+
+		if f := CheckLength(*item, band); f != nil {
+			item.Findings = append(item.Findings, *f)
+			reject = true
+		}
+
+		var skillFindings []types.Finding
+		item.AddedSkills, skillFindings = g.CheckAddedSkills(*item)
+		item.Findings = append(item.Findings, skillFindings...)
+
+		if reject {
+			item.Rejected = true
+			item.TransformedBullet = item.OriginalBullet
+			item.CharCountNew = item.CharCountOriginal
+			item.AddedSkills = nil
+		}
+	}
+	return items
+}
@@ -0,0 +1,145 @@
+package verify
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/p-shah256/tracker/internal/taxonomy"
+	"github.com/p-shah256/tracker/pkg/types"
+)
+
+func findingTexts(findings []types.Finding, kind string) []string {
+	var texts []string
+	for _, f := range findings {
+		if f.Kind == kind {
+			texts = append(texts, f.Text)
+		}
+	}
+	return texts
+}
+
+func TestCheckBullet(t *testing.T) {
+	g := New(taxonomy.Default())
+	resume := "Acme Corp, Backend Engineer. Built services in Go on AWS EKS. Mentored two interns."
+	tests := []struct {
+		name      string
+		original  string
+		rewritten string
+		kind      string
+		want      []string
+	}{
+		{
+			name:      "honest rewrite",
+			original:  "Built a billing service in Go handling 2,000 requests per second",
+			rewritten: "Designed and built a Go billing service serving 2000 requests per second",
+			kind:      KindNumber,
+			want:      nil,
+		},
+		{
+			name:      "invented numbers",
+			original:  "Improved checkout latency",
+			rewritten: "Cut checkout latency by 40% for 3M users",
+			kind:      KindNumber,
+			want:      []string{"40%", "3M"},
+		},
+		{
+			name:      "invented technology",
+			original:  "Built an ingestion pipeline",
+			rewritten: "Built an ingestion pipeline on Kafka and Kubernetes",
+			kind:      KindTechnology,
+			// EKS on the resume backs up Kubernetes
+			want: []string{"Kafka"},
+		},
+		{
+			name:      "invented employer",
+			original:  "Built an ingestion pipeline",
+			rewritten: "Built an ingestion pipeline adopted by Globex Industries and Acme Corp",
+			kind:      KindEntity,
+			want:      []string{"Globex Industries"},
+		},
+		{
+			name:      "invented leadership",
+			original:  "Worked on the payments team",
+			rewritten: "Led the payments team and launched three products",
+			kind:      KindClaim,
+			want:      []string{"launched", "led"},
+		},
+		{
+			name:      "supported claim by another form",
+			original:  "Mentoring new hires on the payments team",
+			rewritten: "Mentored new hires on the payments team",
+			kind:      KindClaim,
+			want:      nil,
+		},
+		{
+			name:      "everyday words are no claims",
+			original:  "Wrote the service that handles refunds",
+			rewritten: "Wrote the only service that handles refunds, after a first-pass review that saved time; lead time dropped",
+			kind:      KindClaim,
+			want:      nil,
+		},
+		{
+			name:      "claims in context",
+			original:  "Wrote the refunds service",
+			rewritten: "First to ship a refunds service as the sole owner, saved over a week per release",
+			kind:      KindClaim,
+			want:      []string{"first to", "saved over", "sole owner"},
+		},
+		{
+			name:      "claim in context backed by the source",
+			original:  "Sole owner of the refunds service",
+			rewritten: "Sole owner of the refunds service end to end",
+			kind:      KindClaim,
+			want:      nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := findingTexts(g.CheckBullet(tt.original, tt.rewritten, resume), tt.kind)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("%s findings = %q, want %q", tt.kind, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckText(t *testing.T) {
+	g := New(taxonomy.Default())
+	resume := "Backend Engineer at Acme. Built Go services."
+	job := "Globex is hiring a Backend Engineer to work on Globex Pay."
+	findings := g.CheckText("I'd love to bring my Go experience to Globex Pay at Globex, where I saved over $1M.", resume, job)
+
+	if got := findingTexts(findings, KindEntity); len(got) != 0 {
+		t.Errorf("names from the job were reported: %q", got)
+	}
+	if got := findingTexts(findings, KindNumber); strings.Join(got, "|") != "$1M" {
+		t.Errorf("number findings = %q, want $1M", got)
+	}
+	if got := findingTexts(findings, KindClaim); strings.Join(got, "|") != "saved over" {
+		t.Errorf("claim findings = %q, want saved over", got)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	g := New(taxonomy.Default())
+	resume := "Built Go services at Acme."
+	items := func() []types.TransformedItem {
+		return []types.TransformedItem{
+			{OriginalBullet: "Built Go services for payments", TransformedBullet: "Built Go services for the payments"},
+			{OriginalBullet: "Built Go services for payments", TransformedBullet: "Built Go services for Globex"},
+		}
+	}
+
+	truthful := g.Verify(items(), resume, true, DefaultLengthBand)
+	if truthful[0].Rejected {
+		t.Errorf("honest rewrite was rejected: %+v", truthful[0].Findings)
+	}
+	if !truthful[1].Rejected || truthful[1].TransformedBullet != truthful[1].OriginalBullet {
+		t.Errorf("fabricated rewrite wasn't rejected and reverted: %+v", truthful[1])
+	}
+
+	aggressive := g.Verify(items(), resume, false, DefaultLengthBand)
+	if aggressive[1].Rejected || len(aggressive[1].Findings) == 0 {
+		t.Errorf("aggressive mode should report without rejecting: %+v", aggressive[1])
+	}
+}
@@ -18,12 +18,16 @@ var (
 	ErrForbidden        = func(detail string) *ApiError { return New(http.StatusForbidden, "Forbidden", detail) }
 	ErrNotFound         = func(detail string) *ApiError { return New(http.StatusNotFound, "Not Found", detail) }
 	ErrMethodNotAllowed = func(detail string) *ApiError { return New(http.StatusMethodNotAllowed, "Method Not Allowed", detail) }
+	ErrConflict         = func(detail string) *ApiError { return New(http.StatusConflict, "Conflict", detail) }
 	ErrInternalServer   = func(detail string) *ApiError {
 		return New(http.StatusInternalServerError, "Internal Server Error", detail)
 	}
 	ErrServiceUnavailable = func(detail string) *ApiError {
 		return New(http.StatusServiceUnavailable, "Service Unavailable", detail)
 	}
+	ErrBadGateway = func(detail string) *ApiError {
+		return New(http.StatusBadGateway, "Bad Gateway", detail)
+	}
 	ErrLLMProcessing = func(detail string) *ApiError {
 		return New(http.StatusInternalServerError, "LLM Processing Failed", detail)
 	}
@@ -1,10 +1,15 @@
 package types
 
+import "time"
+
 // =============== Extraction TYPES ===============
 type ExtractedSkill struct {
 	Name string `json:"name"`
 	// Context    string `json:"context"`
 	Importance int `json:"importance"`
+	// ID and Category are set when the skill taxonomy recognises Name
+	ID       string `json:"id,omitempty"`
+	Category string `json:"category,omitempty"`
 }
 
 type CompanyInfo struct {
@@ -17,6 +22,18 @@ type ExtractedSkills struct {
 	RequiredSkills   []ExtractedSkill `json:"required_skills"`
 	NiceToHaveSkills []ExtractedSkill `json:"nice_to_have_skills"`
 	CompanyInfo      CompanyInfo      `json:"company_info"`
+	JobPosting       *JobPosting      `json:"job_posting,omitempty"`
+}
+
+// JobPosting is the schema.org JobPosting metadata a job board embedded in the page, if any
+type JobPosting struct {
+	Title              string `json:"title"`
+	HiringOrganization string `json:"hiring_organization,omitempty"`
+	Location           string `json:"location,omitempty"`
+	EmploymentType     string `json:"employment_type,omitempty"`
+	Salary             string `json:"salary,omitempty"`
+	DatePosted         string `json:"date_posted,omitempty"`
+	Description        string `json:"description,omitempty"`
 }
 
 // =============== scoring TYPES ===============
@@ -25,20 +42,85 @@ type ScoredResume struct {
 	OverallScore    float64   `json:"overall_score"`
 	OverallComments string    `json:"overall_comments"`
 	PositionLevel   string    `json:"position_level"`
+	// KeywordCoverage is computed locally, unlike everything above it is the same on every run
+	KeywordCoverage *KeywordCoverage `json:"keyword_coverage,omitempty"`
+	// Stability is only set when the score is the median of several samples
+	Stability *ScoreStability `json:"stability,omitempty"`
+}
+
+type ScoreStability struct {
+	Samples  int             `json:"samples"`
+	Failed   int             `json:"failed,omitempty"`
+	Overall  ScoreSpread     `json:"overall"`
+	Sections []SectionSpread `json:"sections"`
+}
+
+type ScoreSpread struct {
+	Median float64   `json:"median"`
+	Min    float64   `json:"min"`
+	Max    float64   `json:"max"`
+	Spread float64   `json:"spread"`
+	StdDev float64   `json:"stddev"`
+	Scores []float64 `json:"scores"`
+	// Unstable means the samples disagree enough that small differences in this score mean nothing
+	Unstable bool `json:"unstable"`
+}
+
+type SectionSpread struct {
+	Name string `json:"name"`
+	ScoreSpread
+}
+
+type KeywordCoverage struct {
+	// Score is on the same 0-10 scale as OverallScore
+	Score float64 `json:"score"`
+	// coverages are 0-1, weighted by importance
+	RequiredCoverage   float64      `json:"required_coverage"`
+	NiceToHaveCoverage float64      `json:"nice_to_have_coverage"`
+	Matches            []SkillMatch `json:"matches"`
+}
+
+type SkillMatch struct {
+	Skill    ExtractedSkill `json:"skill"`
+	Required bool           `json:"required"`
+	Weight   float64        `json:"weight"`
+	Matched  bool           `json:"matched"`
+	Spans    []MatchSpan    `json:"spans,omitempty"`
+}
+
+// MatchSpan is where a skill was found in the resume. Start and End are character
+// (not byte) offsets, End exclusive.
+type MatchSpan struct {
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+	Text  string `json:"text"`
+	// Term is the alias that matched, it differs from the skill name for "k8s" and friends
+	Term string `json:"term"`
 }
 
 type Section struct {
-	Name            string           `json:"name"`
+	Name string `json:"name"`
+	// Kind is experience, project, headline, summary or skills
+	Kind            string           `json:"kind,omitempty"`
 	Score           float64          `json:"score"`
 	ScoreReasoning  string           `json:"score_reasoning"`
 	MissingSkills   []ExtractedSkill `json:"missing_skills,omitempty"`
 	OriginalContent string           `json:"original_content"`
 }
 
+type TransformRequest struct {
+	Section
+	// Resume is the full resume text, claims found anywhere in it are not flagged as invented
+	Resume string `json:"resume,omitempty"`
+	// Mode is "truthful" (default) or "aggressive"
+	Mode string `json:"mode,omitempty"`
+}
+
 type TransformResponse struct {
 	Name           string            `json:"name"`
 	Items          []TransformedItem `json:"items"`
 	ImprovementExp string            `json:"improvement_explanation,omitempty"`
+	Mode           string            `json:"mode,omitempty"`
 }
 
 type TransformedItem struct {
@@ -50,9 +132,598 @@ type TransformedItem struct {
 	AddedSkills       []string `json:"added_skills,omitempty"`
 	OriginalScore     float64  `json:"original_score"`
 	NewScore          float64  `json:"new_score,omitempty"`
+	// Findings are things in TransformedBullet that the original bullet and resume don't back up
+	Findings []Finding `json:"findings,omitempty"`
+	// Rejected rewrites had findings in truthful mode or missed the length band,
+	// TransformedBullet is reset to the original
+	Rejected bool `json:"rejected,omitempty"`
+	// lint issues before and after the rewrite
+	OriginalLint []LintIssue `json:"original_lint,omitempty"`
+	NewLint      []LintIssue `json:"new_lint,omitempty"`
+}
+
+type Finding struct {
+	// Kind is number, entity, technology, claim, length or added_skill
+	Kind    string `json:"kind"`
+	Text    string `json:"text"`
+	Message string `json:"message"`
 }
 
 type OptimizeRequest struct {
 	JobDescText string `json:"jobDescText"`
+	// JobDescURL is fetched server side when JobDescText is empty
+	JobDescURL string `json:"jobDescURL,omitempty"`
+	Resume     string `json:"resume"`
+	// ResumeVersionID scores a stored resume version, Resume may then be left empty
+	ResumeVersionID string `json:"resume_version_id,omitempty"`
+	// Samples > 1 scores the resume that many times and reports the median. Temperatures
+	// and Models, if given, are cycled through across the samples.
+	Samples      int       `json:"samples,omitempty"`
+	Temperatures []float32 `json:"temperatures,omitempty"`
+	Models       []string  `json:"models,omitempty"`
+}
+
+// =============== lint TYPES ===============
+
+type LintRequest struct {
+	// Bullets of a single role, tense and verb repetition are checked across them
+	Bullets []string `json:"bullets"`
+}
+
+type LintResponse struct {
+	Bullets []BulletLint `json:"bullets"`
+	// Issues is the total across all bullets
+	Issues int `json:"issues"`
+}
+
+type BulletLint struct {
+	Bullet string      `json:"bullet"`
+	Issues []LintIssue `json:"issues"`
+}
+
+type LintIssue struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// =============== cover letter TYPES ===============
+
+type CoverLetterRequest struct {
+	JobDescText string `json:"jobDescText"`
+	JobDescURL  string `json:"jobDescURL,omitempty"`
 	Resume      string `json:"resume"`
+	// Scored is a /score result to reuse, without it the resume is scored first
+	Scored *ScoredResume `json:"scored,omitempty"`
+	// Tone is professional (default), enthusiastic, conversational or formal
+	Tone string `json:"tone,omitempty"`
+	// Length is short, medium (default) or long
+	Length string `json:"length,omitempty"`
+	// Format is json (default), markdown or pdf. A letter with findings is never rendered,
+	// it comes back as JSON with status 422
+	Format string `json:"format,omitempty"`
+}
+
+type CoverLetter struct {
+	Company    string   `json:"company,omitempty"`
+	Position   string   `json:"position,omitempty"`
+	Tone       string   `json:"tone"`
+	Length     string   `json:"length"`
+	Greeting   string   `json:"greeting"`
+	Paragraphs []string `json:"paragraphs"`
+	Closing    string   `json:"closing"`
+	Signature  string   `json:"signature"`
+	WordCount  int      `json:"word_count"`
+	// Findings are claims in the letter the resume doesn't support
+	Findings []Finding `json:"findings,omitempty"`
+}
+
+// =============== interview TYPES ===============
+
+type InterviewPrepRequest struct {
+	JobDescText string `json:"jobDescText"`
+	JobDescURL  string `json:"jobDescURL,omitempty"`
+	Resume      string `json:"resume"`
+	// Scored is a /score result to reuse, its missing skills become gap-probing questions
+	Scored *ScoredResume `json:"scored,omitempty"`
+}
+
+type InterviewPrep struct {
+	Company   string              `json:"company,omitempty"`
+	Position  string              `json:"position,omitempty"`
+	Gaps      []ExtractedSkill    `json:"gaps"`
+	Questions []InterviewQuestion `json:"questions"`
+}
+
+type InterviewQuestion struct {
+	// Category is technical, behavioral or gap
+	Category string `json:"category"`
+	Question string `json:"question"`
+	Skill    string `json:"skill,omitempty"`
+	// Why the interviewer is likely to ask it
+	Why           string        `json:"why"`
+	TalkingPoints *TalkingPoint `json:"talking_points,omitempty"`
+}
+
+// TalkingPoint is a STAR answer outline built from the candidate's own bullets.
+type TalkingPoint struct {
+	Situation string `json:"situation"`
+	Task      string `json:"task"`
+	Action    string `json:"action"`
+	Result    string `json:"result"`
+	// SourceBullets are the resume bullets the answer is drawn from, only ones found in the resume are kept
+	SourceBullets []string  `json:"source_bullets"`
+	Findings      []Finding `json:"findings,omitempty"`
+}
+
+// =============== profile TYPES ===============
+
+// ProfileRequest tailors the parts of a resume around the experience: headline, summary
+// and skills. Empty fields are read from the resume text.
+type ProfileRequest struct {
+	JobDescText string   `json:"jobDescText"`
+	JobDescURL  string   `json:"jobDescURL,omitempty"`
+	Resume      string   `json:"resume"`
+	Headline    string   `json:"headline,omitempty"`
+	Summary     string   `json:"summary,omitempty"`
+	Skills      []string `json:"skills,omitempty"`
+	// Mode is "truthful" (default) or "aggressive", as for /transformSection
+	Mode string `json:"mode,omitempty"`
+}
+
+type TailoredProfile struct {
+	Headline TailoredText   `json:"headline"`
+	Summary  TailoredText   `json:"summary"`
+	Skills   TailoredSkills `json:"skills"`
+}
+
+type TailoredText struct {
+	Original       string    `json:"original"`
+	Tailored       string    `json:"tailored"`
+	OriginalScore  float64   `json:"original_score"`
+	NewScore       float64   `json:"new_score"`
+	ScoreReasoning string    `json:"score_reasoning"`
+	Findings       []Finding `json:"findings,omitempty"`
+	// Rejected is set in truthful mode when Tailored had findings, it is reset to Original
+	Rejected bool `json:"rejected,omitempty"`
+}
+
+type TailoredSkills struct {
+	Original []string `json:"original"`
+	// Ordered is Original sorted by how much the job cares about each skill
+	Ordered []RankedSkill `json:"ordered"`
+	// Drop lists skills that have nothing to do with the job
+	Drop []string `json:"drop,omitempty"`
+	// Missing are required skills not on the list, for the user to add only if true
+	Missing []string `json:"missing,omitempty"`
+}
+
+type RankedSkill struct {
+	Name string `json:"name"`
+	// Importance is the job's importance of the matching skill, 0 when nothing matches
+	Importance int  `json:"importance"`
+	Required   bool `json:"required"`
+	// MatchedSkill is the job skill it counts towards, EKS counts towards Kubernetes
+	MatchedSkill string `json:"matched_skill,omitempty"`
+}
+
+// =============== application TYPES ===============
+
+type ApplicationStatus string
+
+const (
+	StatusSaved        ApplicationStatus = "saved"
+	StatusApplied      ApplicationStatus = "applied"
+	StatusScreening    ApplicationStatus = "screening"
+	StatusInterviewing ApplicationStatus = "interviewing"
+	StatusOffer        ApplicationStatus = "offer"
+	StatusRejected     ApplicationStatus = "rejected"
+	StatusWithdrawn    ApplicationStatus = "withdrawn"
+)
+
+// Application is one job being tracked, with the resume and score it was sent with.
+type Application struct {
+	ID string `json:"id"`
+	// UserID is whose application this is, for per-user feeds
+	UserID   string `json:"user_id,omitempty"`
+	Company  string `json:"company"`
+	Position string `json:"position"`
+	Level    string `json:"level,omitempty"`
+	// CompanySize is one of startup, small, medium, large or enterprise
+	CompanySize string `json:"company_size,omitempty"`
+	URL         string `json:"url,omitempty"`
+	// JobID and ScoreRunID are request IDs of the /score call the application came from
+	JobID    string `json:"job_id,omitempty"`
+	ResumeID string `json:"resume_id,omitempty"`
+	// ResumeVersionID is the exact version that was sent
+	ResumeVersionID string            `json:"resume_version_id,omitempty"`
+	ScoreRunID      string            `json:"score_run_id,omitempty"`
+	Score           *float64          `json:"score,omitempty"`
+	Status          ApplicationStatus `json:"status"`
+	Notes           string            `json:"notes,omitempty"`
+	// Deadline is the last day to apply
+	Deadline  *time.Time `json:"deadline,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	// History lists every status the application went through, oldest first
+	History []StatusChange `json:"history,omitempty"`
+	// Duplicates are already tracked applications for what looks like the same posting,
+	// only set when the application is created
+	Duplicates []DuplicateMatch `json:"duplicates,omitempty"`
+}
+
+// DuplicateMatch is a tracked application that looks like the same job posting.
+type DuplicateMatch struct {
+	ApplicationID string            `json:"application_id"`
+	Company       string            `json:"company"`
+	Position      string            `json:"position"`
+	Status        ApplicationStatus `json:"status"`
+	URL           string            `json:"url,omitempty"`
+	// Similarity of the job descriptions (0-1), 0 when either application has none
+	Similarity  float64 `json:"similarity"`
+	SameCompany bool    `json:"same_company"`
+	SameTitle   bool    `json:"same_title"`
+}
+
+// MergeRequest folds the duplicate application into the one it's posted to.
+type MergeRequest struct {
+	DuplicateID string `json:"duplicate_id"`
+}
+
+// ImportResult reports a spreadsheet import. Rows with errors are skipped, the others
+// are imported (or only checked, on a dry run).
+type ImportResult struct {
+	DryRun bool `json:"dry_run"`
+	// Rows is the number of non-empty data rows read
+	Rows     int `json:"rows"`
+	Imported int `json:"imported"`
+	// Columns maps each application field to the spreadsheet column it was read from
+	Columns map[string]string `json:"columns"`
+	// Unmapped lists the spreadsheet columns that were ignored
+	Unmapped     []string      `json:"unmapped,omitempty"`
+	Errors       []ImportError `json:"errors"`
+	Applications []Application `json:"applications"`
+}
+
+// ImportError is why a row wasn't imported. Row is the spreadsheet's own row number,
+// the header being row 1.
+type ImportError struct {
+	Row     int    `json:"row"`
+	Column  string `json:"column,omitempty"`
+	Value   string `json:"value,omitempty"`
+	Message string `json:"message"`
+}
+
+type StatusChange struct {
+	From ApplicationStatus `json:"from,omitempty"`
+	To   ApplicationStatus `json:"to"`
+	At   time.Time         `json:"at"`
+	Note string            `json:"note,omitempty"`
+}
+
+type StatusRequest struct {
+	Status ApplicationStatus `json:"status"`
+	Note   string            `json:"note,omitempty"`
+	// At backdates the change, defaults to now
+	At *time.Time `json:"at,omitempty"`
+	// InterviewAt adds an interview at that time, with a prep reminder ahead of it
+	InterviewAt *time.Time `json:"interview_at,omitempty"`
+}
+
+// Interview is one scheduled round of an application.
+type Interview struct {
+	ID            string `json:"id"`
+	ApplicationID string `json:"application_id"`
+	// Title names the round: "Phone screen", "System design", "Onsite", ...
+	Title    string    `json:"title"`
+	StartsAt time.Time `json:"starts_at"`
+	// EndsAt defaults to an hour after StartsAt
+	EndsAt time.Time `json:"ends_at"`
+	// Location is an address or a meeting link
+	Location  string    `json:"location,omitempty"`
+	Notes     string    `json:"notes,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	// from the application
+	Company  string `json:"company,omitempty"`
+	Position string `json:"position,omitempty"`
+}
+
+// CalendarFeed is a user's calendar subscription, the token is only shown when it's issued.
+type CalendarFeed struct {
+	UserID string `json:"user_id"`
+	Token  string `json:"token"`
+	URL    string `json:"url"`
+}
+
+// =============== reminder TYPES ===============
+
+type ReminderKind string
+
+const (
+	ReminderFollowUp      ReminderKind = "follow_up"
+	ReminderInterviewPrep ReminderKind = "interview_prep"
+	ReminderCustom        ReminderKind = "custom"
+)
+
+type Reminder struct {
+	ID            string `json:"id"`
+	ApplicationID string `json:"application_id"`
+	// InterviewID is set on the prep reminder for an interview
+	InterviewID string       `json:"interview_id,omitempty"`
+	Kind        ReminderKind `json:"kind"`
+	Message     string       `json:"message"`
+	DueAt       time.Time    `json:"due_at"`
+	// FiredAt is set once the notifier took the reminder, or it ran out of attempts
+	FiredAt *time.Time `json:"fired_at,omitempty"`
+	// NextAttemptAt is when a failed delivery is retried
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+	Attempts      int        `json:"attempts,omitempty"`
+	LastError     string     `json:"last_error,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	// from the application, so a notification makes sense on its own
+	Company  string `json:"company,omitempty"`
+	Position string `json:"position,omitempty"`
+}
+
+type ReminderRequest struct {
+	Kind    ReminderKind `json:"kind,omitempty"`
+	Message string       `json:"message"`
+	// DueAt or InDays (from now) say when it fires
+	DueAt  *time.Time `json:"due_at,omitempty"`
+	InDays int        `json:"in_days,omitempty"`
+}
+
+// =============== resume TYPES ===============
+type Resume struct {
+	ID       string          `json:"id"`
+	Name     string          `json:"name"`
+	Headline string          `json:"headline,omitempty"`
+	Contact  []string        `json:"contact,omitempty"`
+	Summary  string          `json:"summary,omitempty"`
+	Sections []ResumeSection `json:"sections"`
+	Skills   []string        `json:"skills,omitempty"`
+}
+
+type ResumeSection struct {
+	Title   string        `json:"title"`
+	Entries []ResumeEntry `json:"entries"`
+}
+
+type ResumeEntry struct {
+	// same as Section.Name from scoring: 'company-position' for experience, else project name
+	Name     string   `json:"name"`
+	Subtitle string   `json:"subtitle,omitempty"`
+	Dates    string   `json:"dates,omitempty"`
+	Bullets  []string `json:"bullets"`
+}
+
+// ResumeVersion is a snapshot of a resume, taken when it is saved and every time a set
+// of transformed bullets is accepted.
+type ResumeVersion struct {
+	ID       string `json:"id"`
+	ResumeID string `json:"resume_id"`
+	ParentID string `json:"parent_id,omitempty"`
+	// Number counts up from 1 per resume
+	Number    int       `json:"number"`
+	Note      string    `json:"note,omitempty"`
+	Resume    Resume    `json:"resume"`
+	CreatedAt time.Time `json:"created_at"`
+	// where this version was sent and how it scored
+	Applications []VersionApplication `json:"applications,omitempty"`
+	Scores       []VersionScore       `json:"scores,omitempty"`
+}
+
+type VersionApplication struct {
+	ID       string            `json:"id"`
+	Company  string            `json:"company"`
+	Position string            `json:"position"`
+	Status   ApplicationStatus `json:"status"`
+	Score    *float64          `json:"score,omitempty"`
+}
+
+type VersionScore struct {
+	RequestID    string    `json:"request_id"`
+	JobID        string    `json:"job_id,omitempty"`
+	Company      string    `json:"company,omitempty"`
+	Position     string    `json:"position,omitempty"`
+	OverallScore float64   `json:"overall_score"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type ResumeDiff struct {
+	ResumeID string `json:"resume_id"`
+	From     int    `json:"from"`
+	To       int    `json:"to"`
+	// Added and Removed count words
+	Added   int      `json:"added"`
+	Removed int      `json:"removed"`
+	Ops     []DiffOp `json:"ops"`
+}
+
+type DiffOp struct {
+	// Op is equal, insert or delete
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+// =============== score history TYPES ===============
+
+// ScoreHistory is every score against one job description, oldest first, to see whether
+// tailoring is moving the number.
+type ScoreHistory struct {
+	JobID    string            `json:"job_id"`
+	Company  string            `json:"company,omitempty"`
+	Position string            `json:"position,omitempty"`
+	Runs     []ScoreHistoryRun `json:"runs"`
+	Overall  ScoreTrend        `json:"overall"`
+	Sections []SectionTrend    `json:"sections"`
+}
+
+type ScoreHistoryRun struct {
+	RequestID       string         `json:"request_id"`
+	ResumeID        string         `json:"resume_id,omitempty"`
+	ResumeVersionID string         `json:"resume_version_id,omitempty"`
+	ResumeVersion   int            `json:"resume_version,omitempty"`
+	Model           string         `json:"model,omitempty"`
+	PromptVersion   string         `json:"prompt_version,omitempty"`
+	OverallScore    float64        `json:"overall_score"`
+	Sections        []SectionScore `json:"sections"`
+	// Change is against the previous run, nil on the first
+	Change *float64 `json:"change,omitempty"`
+	// a different prompt or model than the previous run, so the change isn't down to the resume alone
+	PromptChanged bool      `json:"prompt_changed,omitempty"`
+	ModelChanged  bool      `json:"model_changed,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type SectionScore struct {
+	Name  string  `json:"name"`
+	Kind  string  `json:"kind,omitempty"`
+	Score float64 `json:"score"`
+}
+
+type ScoreTrend struct {
+	Runs   int     `json:"runs"`
+	First  float64 `json:"first"`
+	Last   float64 `json:"last"`
+	Change float64 `json:"change"`
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+}
+
+// SectionTrend follows one section across the runs it was scored in.
+type SectionTrend struct {
+	Name string `json:"name"`
+	Kind string `json:"kind,omitempty"`
+	ScoreTrend
+	Scores []ScorePoint `json:"scores"`
+}
+
+type ScorePoint struct {
+	RequestID     string    `json:"request_id"`
+	ResumeVersion int       `json:"resume_version,omitempty"`
+	Score         float64   `json:"score"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// =============== analytics TYPES ===============
+
+// Funnel is how applications move through the pipeline: where they stall, who answers,
+// and whether a better scoring resume gets further.
+type Funnel struct {
+	Applications int `json:"applications"`
+	// Sent is how many got past saved
+	Sent   int           `json:"sent"`
+	Stages []FunnelStage `json:"stages"`
+	// ByLevel and ByCompanySize group the sent applications, "unknown" when it wasn't recorded
+	ByLevel       []ResponseRate `json:"by_level"`
+	ByCompanySize []ResponseRate `json:"by_company_size"`
+	Score         ScoreOutcome   `json:"score"`
+}
+
+// FunnelStage is one pipeline status. Skipping a stage (applied straight to interviewing)
+// counts as passing through it.
+type FunnelStage struct {
+	Status ApplicationStatus `json:"status"`
+	// Reached counts the applications that got at least this far
+	Reached int `json:"reached"`
+	// Current counts the ones in this stage now
+	Current int `json:"current"`
+	// Advanced counts the ones that went further
+	Advanced int `json:"advanced"`
+	// ConversionRate is Advanced / Reached, nil for the last stage or when none reached it
+	ConversionRate *float64 `json:"conversion_rate,omitempty"`
+	// Rejected and Withdrawn count the applications closed while in this stage
+	Rejected  int `json:"rejected"`
+	Withdrawn int `json:"withdrawn"`
+	// MedianDays spent in the stage, over the applications that have left it
+	MedianDays *float64 `json:"median_days,omitempty"`
+}
+
+// ResponseRate is how one group of sent applications fared. Any reply counts as a
+// response, a rejection included.
+type ResponseRate struct {
+	Group         string   `json:"group"`
+	Sent          int      `json:"sent"`
+	Responses     int      `json:"responses"`
+	ResponseRate  *float64 `json:"response_rate,omitempty"`
+	Interviews    int      `json:"interviews"`
+	InterviewRate *float64 `json:"interview_rate,omitempty"`
+}
+
+// ScoreOutcome relates the score a sent application had to whether it reached an interview.
+type ScoreOutcome struct {
+	// Scored is how many sent applications have a score
+	Scored int `json:"scored"`
+	// Correlation is the point-biserial correlation between score and reaching an interview,
+	// -1 to 1. Nil with fewer than 3 scored applications or when they all scored or ended alike.
+	Correlation        *float64      `json:"correlation,omitempty"`
+	InterviewedMean    *float64      `json:"interviewed_mean,omitempty"`
+	NotInterviewedMean *float64      `json:"not_interviewed_mean,omitempty"`
+	Buckets            []ScoreBucket `json:"buckets"`
+}
+
+// ScoreBucket holds scores from Min up to but not including Max, the top bucket includes 10.
+type ScoreBucket struct {
+	Min           float64  `json:"min"`
+	Max           float64  `json:"max"`
+	Sent          int      `json:"sent"`
+	Interviews    int      `json:"interviews"`
+	InterviewRate *float64 `json:"interview_rate,omitempty"`
+}
+
+// =============== contact TYPES ===============
+
+type ContactRole string
+
+const (
+	ContactRecruiter     ContactRole = "recruiter"
+	ContactHiringManager ContactRole = "hiring_manager"
+	ContactReferrer      ContactRole = "referrer"
+	ContactOther         ContactRole = "other"
+)
+
+// Contact is someone at (or into) a company: a recruiter, a hiring manager or a referrer.
+// POST it to /applications/{id}/contacts with just an id to link an existing contact.
+type Contact struct {
+	ID       string      `json:"id"`
+	UserID   string      `json:"user_id,omitempty"`
+	Name     string      `json:"name"`
+	Role     ContactRole `json:"role"`
+	Company  string      `json:"company,omitempty"`
+	Title    string      `json:"title,omitempty"`
+	Email    string      `json:"email,omitempty"`
+	Phone    string      `json:"phone,omitempty"`
+	LinkedIn string      `json:"linkedin,omitempty"`
+	Notes    string      `json:"notes,omitempty"`
+	// LastContactedAt is the latest logged interaction, nil when there's none
+	LastContactedAt *time.Time `json:"last_contacted_at,omitempty"`
+	Interactions    int        `json:"interactions"`
+	// ApplicationIDs are the applications the contact is linked to
+	ApplicationIDs []string  `json:"application_ids,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type InteractionKind string
+
+const (
+	InteractionEmail   InteractionKind = "email"
+	InteractionCall    InteractionKind = "call"
+	InteractionMeeting InteractionKind = "meeting"
+	InteractionMessage InteractionKind = "message"
+	InteractionNote    InteractionKind = "note"
+)
+
+// Interaction is one entry in a contact's log, optionally about one application.
+type Interaction struct {
+	ID            string          `json:"id"`
+	ContactID     string          `json:"contact_id"`
+	ApplicationID string          `json:"application_id,omitempty"`
+	Kind          InteractionKind `json:"kind"`
+	Summary       string          `json:"summary"`
+	// At defaults to now
+	At        time.Time `json:"at"`
+	CreatedAt time.Time `json:"created_at"`
+	// from the contact
+	ContactName string `json:"contact_name,omitempty"`
 }